@@ -0,0 +1,31 @@
+package goset
+
+// IntersectionAccumulator maintains the running intersection of Sets that are added to it over time.
+// The zero value is ready to use
+type IntersectionAccumulator[T comparable] struct {
+	result *Set[T]
+}
+
+// Add intersects the accumulated result with the Set. The first added Set initializes the result.
+// Since the result only shrinks, each Add only probes the items that are still in it
+func (a *IntersectionAccumulator[T]) Add(set *Set[T]) {
+	if a.result == nil {
+		a.result = set.Copy()
+		return
+	}
+	var missing []T
+	a.result.For(func(item T) {
+		if !set.Contains(item) {
+			missing = append(missing, item)
+		}
+	})
+	a.result.Discard(missing...)
+}
+
+// Result returns a new Set with the intersection of all the added Sets, or an empty Set if none were added
+func (a *IntersectionAccumulator[T]) Result() *Set[T] {
+	if a.result == nil {
+		return NewSet[T]()
+	}
+	return a.result.Copy()
+}
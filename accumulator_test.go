@@ -0,0 +1,30 @@
+package goset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntersectionAccumulator(t *testing.T) {
+	var acc IntersectionAccumulator[int]
+	require.True(t, acc.Result().IsEmpty())
+
+	first := RangeSet(0, 20, 1)
+	acc.Add(first)
+	require.True(t, acc.Result().Equal(first))
+	first.Add(100) // the accumulator keeps its own copy
+	require.False(t, acc.Result().Contains(100))
+
+	acc.Add(RangeSet(0, 20, 2))
+	require.True(t, acc.Result().Equal(RangeSet(0, 20, 2)))
+	acc.Add(RangeSet(0, 30, 3))
+	require.True(t, acc.Result().Equal(NewSet[int](0, 6, 12, 18)))
+
+	result := acc.Result()
+	result.Clear()
+	require.Equal(t, 4, acc.Result().Len())
+
+	acc.Add(NewSet[int](1, 2))
+	require.True(t, acc.Result().IsEmpty())
+}
@@ -0,0 +1,50 @@
+package goset
+
+import (
+	"encoding/binary"
+	"fmt"
+	"github.com/amit7itz/goset/store"
+	"unsafe"
+)
+
+const binaryLenPrefixSize = 8
+
+// MarshalBinaryInts returns a compact binary encoding of a Set of integers:
+// the number of items as a little-endian uint64, followed by the little-endian items, each in the width of T.
+// Note that the width of int, uint and uintptr depends on the platform
+// See also: UnmarshalBinaryInts()
+func MarshalBinaryInts[T Integer](s *Set[T]) ([]byte, error) {
+	var zero T
+	width := int(unsafe.Sizeof(zero))
+	b := make([]byte, binaryLenPrefixSize, binaryLenPrefixSize+s.Len()*width)
+	binary.LittleEndian.PutUint64(b, uint64(s.Len()))
+	var buf [8]byte
+	s.For(func(item T) {
+		binary.LittleEndian.PutUint64(buf[:], uint64(item))
+		b = append(b, buf[:width]...)
+	})
+	return b, nil
+}
+
+// UnmarshalBinaryInts adds the items encoded by MarshalBinaryInts() to the Set
+func UnmarshalBinaryInts[T Integer](b []byte, s *Set[T]) error {
+	var zero T
+	width := int(unsafe.Sizeof(zero))
+	if len(b) < binaryLenPrefixSize {
+		return fmt.Errorf("binary data too short: %d bytes", len(b))
+	}
+	count := binary.LittleEndian.Uint64(b)
+	b = b[binaryLenPrefixSize:]
+	if len(b)%width != 0 || count != uint64(len(b)/width) {
+		return fmt.Errorf("binary data has %d bytes, expected %d items of %d bytes", len(b), count, width)
+	}
+	if s.store == nil {
+		s.store = store.NewSimpleStore[T]()
+	}
+	var buf [8]byte
+	for i := 0; i < len(b); i += width {
+		copy(buf[:], b[i:i+width])
+		s.Add(T(binary.LittleEndian.Uint64(buf[:])))
+	}
+	return nil
+}
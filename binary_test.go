@@ -0,0 +1,48 @@
+package goset
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalBinaryInts(t *testing.T) {
+	s1 := NewSet[uint32](0, 1, 42, math.MaxUint32)
+	b, err := MarshalBinaryInts(s1)
+	require.NoError(t, err)
+	require.Len(t, b, 8+4*4)
+	s2 := NewSet[uint32]()
+	require.NoError(t, UnmarshalBinaryInts(b, s2))
+	require.True(t, s1.Equal(s2))
+
+	s3 := NewSet[int64](-1, 0, 1, math.MinInt64, math.MaxInt64)
+	b, err = MarshalBinaryInts(s3)
+	require.NoError(t, err)
+	require.Len(t, b, 8+5*8)
+	s4 := NewSet[int64]()
+	require.NoError(t, UnmarshalBinaryInts(b, s4))
+	require.True(t, s3.Equal(s4))
+
+	b, err = MarshalBinaryInts(NewSet[int64]())
+	require.NoError(t, err)
+	require.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0, 0}, b)
+}
+
+func TestUnmarshalBinaryInts(t *testing.T) {
+	s := NewSet[int16]()
+	require.NoError(t, UnmarshalBinaryInts([]byte{2, 0, 0, 0, 0, 0, 0, 0, 0xff, 0xff, 7, 0}, s))
+	require.True(t, s.Equal(NewSet[int16](-1, 7)))
+	require.Error(t, UnmarshalBinaryInts([]byte{1, 0}, s))
+	require.Error(t, UnmarshalBinaryInts([]byte{2, 0, 0, 0, 0, 0, 0, 0, 0xff, 0xff}, s))
+	require.Error(t, UnmarshalBinaryInts([]byte{1, 0, 0, 0, 0, 0, 0, 0, 0xff, 0xff, 7}, s))
+
+	// a count of 2^61 items of 8 bytes overflows uint64 to 0 bytes
+	forged := NewSet[int64]()
+	require.Error(t, UnmarshalBinaryInts([]byte{0, 0, 0, 0, 0, 0, 0, 0x20}, forged))
+	require.Equal(t, 0, forged.Len())
+
+	var zero Set[int16]
+	require.NoError(t, UnmarshalBinaryInts([]byte{1, 0, 0, 0, 0, 0, 0, 0, 7, 0}, &zero))
+	require.True(t, zero.Equal(NewSet[int16](7)))
+}
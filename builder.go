@@ -0,0 +1,49 @@
+package goset
+
+import "github.com/amit7itz/goset/store"
+
+// SetBuilder is used to efficiently build a large Set, similarly to strings.Builder.
+// The zero value is ready to use. Build() hands the collected items to the Set without copying them
+type SetBuilder[T comparable] struct {
+	store *store.SimpleSetStore[T]
+}
+
+// NewSetBuilder returns a SetBuilder with room for at least capacity items
+func NewSetBuilder[T comparable](capacity int) *SetBuilder[T] {
+	return &SetBuilder[T]{store: store.NewSimpleStoreWithCapacity[T](capacity)}
+}
+
+func (b *SetBuilder[T]) init() {
+	if b.store == nil {
+		b.store = store.NewSimpleStore[T]()
+	}
+}
+
+// Add adds item(s) to the builder
+func (b *SetBuilder[T]) Add(items ...T) {
+	b.init()
+	b.store.Add(items...)
+}
+
+// AddSlice adds all the items of the slice to the builder
+func (b *SetBuilder[T]) AddSlice(items []T) {
+	b.init()
+	b.store.Add(items...)
+}
+
+// Len returns the number of items added to the builder
+func (b *SetBuilder[T]) Len() int {
+	if b.store == nil {
+		return 0
+	}
+	return b.store.Len()
+}
+
+// Build returns a Set with all the items added to the builder.
+// The builder is reset, so adding more items to it doesn't affect the returned Set
+func (b *SetBuilder[T]) Build() *Set[T] {
+	b.init()
+	set := &Set[T]{store: b.store}
+	b.store = nil
+	return set
+}
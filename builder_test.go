@@ -0,0 +1,53 @@
+package goset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetBuilder(t *testing.T) {
+	var b SetBuilder[string]
+	require.Equal(t, 0, b.Len())
+	b.Add("a", "b")
+	b.AddSlice([]string{"b", "c"})
+	require.Equal(t, 3, b.Len())
+	s := b.Build()
+	require.True(t, s.Equal(NewSet[string]("a", "b", "c")))
+
+	// the builder is reset by Build
+	require.Equal(t, 0, b.Len())
+	b.Add("d")
+	require.False(t, s.Contains("d"))
+	require.True(t, b.Build().Equal(NewSet[string]("d")))
+	require.True(t, b.Build().IsEmpty())
+}
+
+func TestNewSetBuilder(t *testing.T) {
+	b := NewSetBuilder[int](10)
+	for i := 0; i < 100; i++ {
+		b.Add(i % 20)
+	}
+	require.True(t, b.Build().Equal(RangeSet(0, 20, 1)))
+}
+
+func BenchmarkNewSet_Add(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s := NewSet[int]()
+		for j := 0; j < 10000; j++ {
+			s.Add(j)
+		}
+	}
+}
+
+func BenchmarkSetBuilder(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		builder := NewSetBuilder[int](10000)
+		for j := 0; j < 10000; j++ {
+			builder.Add(j)
+		}
+		builder.Build()
+	}
+}
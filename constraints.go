@@ -0,0 +1,6 @@
+package goset
+
+// Integer is a constraint that permits any integer type
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
@@ -0,0 +1,247 @@
+package goset
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"sort"
+)
+
+// ForSorted runs a function on all the items in the Set, in the order defined by less.
+// It works with any store, by sorting a copy of the Set items
+func ForSorted[T comparable](s *Set[T], less func(a, b T) bool, f func(item T)) {
+	items := s.Items()
+	sort.Slice(items, func(i, j int) bool {
+		return less(items[i], items[j])
+	})
+	for _, item := range items {
+		f(item)
+	}
+}
+
+// PartitionByHash splits the Set into shards new Sets, putting each item in the Set at index hash(item) % shards.
+// The same item always lands in the same shard. Returns an empty slice if shards is not positive
+func PartitionByHash[T comparable](s *Set[T], shards int, hash func(item T) uint64) []*Set[T] {
+	if shards <= 0 {
+		return []*Set[T]{}
+	}
+	parts := make([]*Set[T], shards)
+	for i := range parts {
+		parts[i] = NewSet[T]()
+	}
+	s.For(func(item T) {
+		parts[hash(item)%uint64(shards)].Add(item)
+	})
+	return parts
+}
+
+// TrimBefore removes all the items that were inserted to the Set before the given item, and returns the number of items removed.
+// It only applies to Sets that keep the insertion order, like the ones created by NewRoundRobinSet() or NewBoundedSet().
+// Returns 0 for other Sets, or if the item is not in the Set
+func TrimBefore[T comparable](s *Set[T], item T) int {
+	trimmer, ok := s.store.(interface{ TrimBefore(item T) int })
+	if !ok {
+		return 0
+	}
+	count := trimmer.TrimBefore(item)
+	if count > 0 {
+		s.version++
+	}
+	return count
+}
+
+// RangeSet returns a new Set of the integers start, start+step, start+2*step... up to end (exclusive).
+// Returns an empty Set if step is not positive or start >= end
+func RangeSet[T Integer](start, end, step T) *Set[T] {
+	set := NewSet[T]()
+	if step <= 0 || start >= end {
+		return set
+	}
+	for i := start; ; {
+		set.Add(i)
+		next := i + step
+		if next <= i || next >= end { // next <= i when the addition overflows
+			break
+		}
+		i = next
+	}
+	return set
+}
+
+// Flatten returns a new Set with the items of all the given Sets
+func Flatten[T comparable](sets ...*Set[T]) *Set[T] {
+	return NewSet[T]().Union(sets...)
+}
+
+// FilterMap returns a new Set of the values returned by f for the items of s, keeping only the values for which f returned true.
+// It filters and transforms the items in a single pass
+func FilterMap[T, U comparable](s *Set[T], f func(item T) (U, bool)) *Set[U] {
+	result := NewSet[U]()
+	s.For(func(item T) {
+		if value, keep := f(item); keep {
+			result.Add(value)
+		}
+	})
+	return result
+}
+
+// CommonElements returns a new Set with the items that are in all the given Sets, or an empty Set if none are given.
+// It starts from the smallest Set and intersects it with the others from the smallest to the largest,
+// so the intermediate result only shrinks and the work stops as soon as it is empty
+func CommonElements[T comparable](sets []*Set[T]) *Set[T] {
+	if len(sets) == 0 {
+		return NewSet[T]()
+	}
+	sorted := slices.Clone(sets)
+	slices.SortFunc(sorted, func(a, b *Set[T]) int {
+		return a.Len() - b.Len()
+	})
+	result := sorted[0].Copy()
+	for _, set := range sorted[1:] {
+		if result.IsEmpty() {
+			break
+		}
+		var missing []T
+		result.For(func(item T) {
+			if !set.Contains(item) {
+				missing = append(missing, item)
+			}
+		})
+		result.Discard(missing...)
+	}
+	return result
+}
+
+// ConvertSet returns a new Set with the results of conv on the items of s,
+// typically a type conversion such as between a defined string type and string
+func ConvertSet[T, U comparable](s *Set[T], conv func(item T) U) *Set[U] {
+	return FilterMap(s, func(item T) (U, bool) {
+		return conv(item), true
+	})
+}
+
+// ItemsFunc returns a slice of the results of f on the items of s.
+// The results are not deduplicated, since U may not be comparable. An empty Set returns an empty (non-nil) slice
+func ItemsFunc[T comparable, U any](s *Set[T], f func(item T) U) []U {
+	results := make([]U, 0, s.Len())
+	s.For(func(item T) {
+		results = append(results, f(item))
+	})
+	return results
+}
+
+// ArePairwiseDisjoint returns whether no item exists in more than one of the given Sets
+func ArePairwiseDisjoint[T comparable](sets ...*Set[T]) bool {
+	seen := NewSet[T]()
+	for _, set := range sets {
+		disjoint := true
+		set.ForWithBreak(func(item T) bool {
+			if seen.Contains(item) {
+				disjoint = false
+				return false // stop iteration
+			}
+			return true
+		})
+		if !disjoint {
+			return false
+		}
+		seen.Update(set)
+	}
+	return true
+}
+
+// IntersectionOf returns a new Set with the common items of all the given Sets.
+// Returns an empty Set when no Sets are given, and a copy of the Set when only one is given
+func IntersectionOf[T comparable](sets ...*Set[T]) *Set[T] {
+	if len(sets) == 0 {
+		return NewSet[T]()
+	}
+	// Intersection() iterates the smallest of the Sets, so the first one isn't special
+	return sets[0].Intersection(sets[1:]...)
+}
+
+// IntersectMapKeys returns a new Set of the items of s that are also keys of m
+func IntersectMapKeys[K comparable, V any](s *Set[K], m map[K]V) *Set[K] {
+	result := NewSet[K]()
+	if len(m) < s.Len() {
+		for key := range m {
+			if s.Contains(key) {
+				result.Add(key)
+			}
+		}
+		return result
+	}
+	s.For(func(item K) {
+		if _, ok := m[item]; ok {
+			result.Add(item)
+		}
+	})
+	return result
+}
+
+// IsCover returns whether the union of the parts is exactly the universe:
+// every item of the universe is in at least one of the parts, and the parts have no items outside the universe
+func IsCover[T comparable](universe *Set[T], parts ...*Set[T]) bool {
+	return Flatten(parts...).Equal(universe)
+}
+
+// IsPartition returns whether the parts are pairwise disjoint and cover the universe
+// See also: ArePairwiseDisjoint(), IsCover()
+func IsPartition[T comparable](universe *Set[T], parts ...*Set[T]) bool {
+	return ArePairwiseDisjoint(parts...) && IsCover(universe, parts...)
+}
+
+// WeightedSample returns a random item of the Set, chosen with probability proportional to its weight.
+// Items with a non-positive weight are never chosen. Returns error if the Set is empty or no item has a positive weight
+func WeightedSample[T comparable](s *Set[T], weight func(item T) float64, r *rand.Rand) (T, error) {
+	var chosen T
+	if s.IsEmpty() {
+		return chosen, errors.New("set is empty")
+	}
+	items := make([]T, 0, s.Len())
+	weights := make([]float64, 0, s.Len())
+	total := 0.0
+	s.For(func(item T) {
+		if w := weight(item); w > 0 {
+			items = append(items, item)
+			weights = append(weights, w)
+			total += w
+		}
+	})
+	if len(items) == 0 {
+		return chosen, errors.New("no item has a positive weight")
+	}
+	target := r.Float64() * total
+	for i, w := range weights {
+		if target < w {
+			return items[i], nil
+		}
+		target -= w
+	}
+	// floating point rounding may leave a tiny remainder, which belongs to the last item
+	return items[len(items)-1], nil
+}
+
+// AllSatisfy returns true if ok returns true for every item in the Set (true for an empty Set).
+// Stops at the first item that fails, e.g. AllSatisfy(s, func(v any) bool { _, ok := v.(string); return ok })
+func AllSatisfy[T comparable](s *Set[T], ok func(item T) bool) bool {
+	all := true
+	s.ForWithBreak(func(item T) bool {
+		all = ok(item)
+		return all
+	})
+	return all
+}
+
+// Dedup returns a new slice with the items of slice without duplicates, in the order of their first appearance
+func Dedup[T comparable](slice []T) []T {
+	seen := NewSet[T]()
+	result := make([]T, 0, len(slice))
+	for _, item := range slice {
+		if !seen.Contains(item) {
+			seen.Add(item)
+			result = append(result, item)
+		}
+	}
+	return result
+}
@@ -0,0 +1,262 @@
+package goset
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/amit7itz/goset/store"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForSorted(t *testing.T) {
+	simple := NewSet[int](3, 1, 2)
+	ordered := FromSliceWithStore([]int{3, 1, 2}, store.NewOrderedStore[int]())
+	for _, s := range []*Set[int]{simple, ordered} {
+		var ascending, descending []int
+		ForSorted(s, func(a, b int) bool { return a < b }, func(item int) {
+			ascending = append(ascending, item)
+		})
+		ForSorted(s, func(a, b int) bool { return a > b }, func(item int) {
+			descending = append(descending, item)
+		})
+		require.Equal(t, []int{1, 2, 3}, ascending)
+		require.Equal(t, []int{3, 2, 1}, descending)
+	}
+	require.Equal(t, []int{3, 1, 2}, ordered.Items()) // the store order is unchanged
+}
+
+func TestPartitionByHash(t *testing.T) {
+	hash := func(item string) uint64 {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(item))
+		return h.Sum64()
+	}
+	s := NewSet[string]("alice", "bob", "carol", "dave", "eve", "frank")
+	shards := PartitionByHash(s, 3, hash)
+	require.Len(t, shards, 3)
+	require.True(t, IsPartition(s, shards...))
+	for i, shard := range shards {
+		shard.For(func(item string) {
+			require.Equal(t, uint64(i), hash(item)%3)
+		})
+	}
+
+	// the assignment is deterministic across calls
+	for run := 0; run < 5; run++ {
+		again := PartitionByHash(s.Copy(), 3, hash)
+		for i := range shards {
+			require.True(t, shards[i].Equal(again[i]))
+		}
+	}
+	require.Empty(t, PartitionByHash(s, 0, hash))
+}
+
+func TestTrimBefore(t *testing.T) {
+	s := NewRoundRobinSet[int](1, 2, 3, 4, 5)
+	v := s.Version()
+	require.Equal(t, 2, TrimBefore(s, 3))
+	require.Equal(t, []int{3, 4, 5}, s.Items())
+	require.Greater(t, s.Version(), v)
+	require.Equal(t, 0, TrimBefore(s, 3))
+	require.Equal(t, 0, TrimBefore(s, 42))
+	require.Equal(t, 2, TrimBefore(s, 5))
+	require.Equal(t, []int{5}, s.Items())
+
+	bounded := NewBoundedSet[int](3, 1, 2, 3, 4)
+	require.Equal(t, 1, TrimBefore(bounded, 3))
+	require.Equal(t, []int{3, 4}, bounded.Items())
+
+	unordered := NewSet[int](1, 2, 3)
+	require.Equal(t, 0, TrimBefore(unordered, 3))
+	require.Equal(t, 3, unordered.Len())
+}
+
+func TestRangeSet(t *testing.T) {
+	require.True(t, RangeSet(0, 5, 1).Equal(NewSet[int](0, 1, 2, 3, 4)))
+	require.True(t, RangeSet(-3, 8, 3).Equal(NewSet[int](-3, 0, 3, 6)))
+	require.True(t, RangeSet[uint8](250, 255, 2).Equal(NewSet[uint8](250, 252, 254)))
+	require.True(t, RangeSet[int8](120, 127, 5).Equal(NewSet[int8](120, 125)))
+	require.True(t, RangeSet(5, 5, 1).IsEmpty())
+	require.True(t, RangeSet(5, 0, 1).IsEmpty())
+	require.True(t, RangeSet(0, 5, 0).IsEmpty())
+	require.True(t, RangeSet(0, 5, -1).IsEmpty())
+}
+
+func TestFlatten(t *testing.T) {
+	s1 := NewSet[string]("a", "b")
+	s2 := NewSet[string]("b", "c")
+	s3 := NewSet[string]("c", "d", "a")
+	flat := Flatten(s1, s2, s3)
+	require.True(t, flat.Equal(NewSet[string]("a", "b", "c", "d")))
+	require.Equal(t, 2, s1.Len())
+	require.True(t, Flatten[string]().IsEmpty())
+}
+
+func TestFilterMap(t *testing.T) {
+	s := NewSet[string]("1", "two", "3", "", "-4", "3")
+	numbers := FilterMap(s, func(item string) (int, bool) {
+		n, err := strconv.Atoi(item)
+		return n, err == nil
+	})
+	require.True(t, numbers.Equal(NewSet[int](1, 3, -4)))
+	require.True(t, FilterMap(NewSet[string](), func(item string) (int, bool) { return 0, true }).IsEmpty())
+}
+
+func TestCommonElements(t *testing.T) {
+	sets := []*Set[int]{RangeSet(0, 100, 1), RangeSet(0, 100, 2), RangeSet(0, 100, 3), NewSet[int](0, 6, 7, 12)}
+	require.True(t, CommonElements(sets).Equal(NewSet[int](0, 6, 12)))
+	require.Equal(t, 100, sets[0].Len()) // the inputs are unchanged
+	require.True(t, CommonElements([]*Set[int]{NewSet[int](1, 2)}).Equal(NewSet[int](1, 2)))
+	require.True(t, CommonElements([]*Set[int]{NewSet[int](1), NewSet[int](2)}).IsEmpty())
+	require.True(t, CommonElements[int](nil).IsEmpty())
+}
+
+func commonElementsBenchmarkSets() []*Set[int] {
+	return []*Set[int]{RangeSet(0, 100000, 1), RangeSet(0, 100000, 2), RangeSet(0, 100000, 3), RangeSet(0, 100, 5)}
+}
+
+func BenchmarkCommonElements(b *testing.B) {
+	sets := commonElementsBenchmarkSets()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		CommonElements(sets)
+	}
+}
+
+func BenchmarkCommonElements_LeftFold(b *testing.B) {
+	sets := commonElementsBenchmarkSets()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		result := sets[0]
+		for _, set := range sets[1:] {
+			result = result.Intersection(set)
+		}
+	}
+}
+
+func TestConvertSet(t *testing.T) {
+	type Color string
+	colors := NewSet[Color]("red", "green")
+	strs := ConvertSet(colors, func(c Color) string { return string(c) })
+	require.True(t, strs.Equal(NewSet[string]("red", "green")))
+	back := ConvertSet(strs, func(s string) Color { return Color(s) })
+	require.True(t, back.Equal(colors))
+}
+
+func TestItemsFunc(t *testing.T) {
+	type User struct {
+		ID   int
+		Name string
+	}
+	users := NewSet(User{ID: 1, Name: "Amit"}, User{ID: 2, Name: "Amit"}, User{ID: 3, Name: "Dana"})
+	names := ItemsFunc(users, func(u User) string { return u.Name })
+	require.ElementsMatch(t, []string{"Amit", "Amit", "Dana"}, names)
+
+	empty := ItemsFunc(NewSet[User](), func(u User) string { return u.Name })
+	require.NotNil(t, empty)
+	require.Empty(t, empty)
+}
+
+func TestArePairwiseDisjoint(t *testing.T) {
+	s1 := NewSet[int](1, 2)
+	s2 := NewSet[int](3, 4)
+	s3 := NewSet[int](5)
+	require.True(t, ArePairwiseDisjoint(s1, s2, s3))
+	require.True(t, ArePairwiseDisjoint(s1, NewSet[int](), s3))
+	require.True(t, ArePairwiseDisjoint[int]())
+	require.False(t, ArePairwiseDisjoint(s1, s2, s3, NewSet[int](6, 1)))
+	require.False(t, ArePairwiseDisjoint(s1, s1))
+}
+
+func TestIntersectionOf(t *testing.T) {
+	s1 := NewSet[int](1, 2, 3, 4)
+	s2 := NewSet[int](2, 3, 4, 5)
+	s3 := NewSet[int](3, 4)
+	require.True(t, IntersectionOf(s1, s2, s3).Equal(NewSet[int](3, 4)))
+	require.True(t, IntersectionOf[int]().IsEmpty())
+	single := IntersectionOf(s1)
+	require.True(t, single.Equal(s1))
+	require.True(t, single != s1)
+}
+
+func TestIntersectMapKeys(t *testing.T) {
+	allowed := NewSet[string]("a", "b", "c")
+	cache := map[string]int{"b": 2, "c": 3, "d": 4}
+	require.True(t, IntersectMapKeys(allowed, cache).Equal(NewSet[string]("b", "c")))
+	bigCache := map[string]int{"a": 1, "c": 3, "d": 4, "e": 5}
+	require.True(t, IntersectMapKeys(allowed, bigCache).Equal(NewSet[string]("a", "c")))
+	require.True(t, IntersectMapKeys[string, int](allowed, nil).IsEmpty())
+}
+
+func TestIsCover(t *testing.T) {
+	universe := NewSet[int](1, 2, 3, 4, 5)
+	require.True(t, IsCover(universe, NewSet[int](1, 2, 3), NewSet[int](3, 4, 5)))
+	require.False(t, IsCover(universe, NewSet[int](1, 2), NewSet[int](4, 5)))       // missing 3
+	require.False(t, IsCover(universe, NewSet[int](1, 2, 3), NewSet[int](4, 5, 6))) // extra 6
+	require.True(t, IsCover(NewSet[int]()))
+	require.False(t, IsCover(universe))
+}
+
+func TestIsPartition(t *testing.T) {
+	universe := NewSet[int](1, 2, 3, 4, 5)
+	require.True(t, IsPartition(universe, NewSet[int](1, 2), NewSet[int](3, 4, 5)))
+	require.False(t, IsPartition(universe, NewSet[int](1, 2, 3), NewSet[int](3, 4, 5))) // overlapping
+	require.False(t, IsPartition(universe, NewSet[int](1, 2), NewSet[int](4, 5)))       // missing 3
+}
+
+func TestWeightedSample(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	weights := map[string]float64{"a": 1, "b": 3, "c": 6, "d": 0, "e": -2}
+	s := NewSet[string]("a", "b", "c", "d", "e")
+	weight := func(item string) float64 { return weights[item] }
+
+	const iterations = 100000
+	counts := map[string]int{}
+	for i := 0; i < iterations; i++ {
+		item, err := WeightedSample(s, weight, r)
+		require.NoError(t, err)
+		counts[item]++
+	}
+	require.Zero(t, counts["d"])
+	require.Zero(t, counts["e"])
+	for _, item := range []string{"a", "b", "c"} {
+		expected := iterations * weights[item] / 10
+		require.InDelta(t, expected, counts[item], expected*0.05)
+	}
+
+	_, err := WeightedSample(NewSet[string](), weight, r)
+	require.Error(t, err)
+	_, err = WeightedSample(NewSet[string]("d", "e"), weight, r)
+	require.Error(t, err)
+}
+
+func TestAllSatisfy(t *testing.T) {
+	isString := func(v any) bool {
+		_, ok := v.(string)
+		return ok
+	}
+	require.True(t, AllSatisfy(NewSet[any](), isString))
+	require.True(t, AllSatisfy(NewSet[any]("a", "b"), isString))
+	require.False(t, AllSatisfy(NewSet[any]("a", 1, "b"), isString))
+	require.False(t, AllSatisfy(NewSet[any]("a", nil), isString))
+
+	calls := 0
+	require.False(t, AllSatisfy(NewSet[any](1, 2, 3), func(v any) bool {
+		calls++
+		return false
+	}))
+	require.Equal(t, 1, calls)
+}
+
+func TestDedup(t *testing.T) {
+	require.Empty(t, Dedup[int](nil))
+	require.Equal(t, []int{1, 2, 3}, Dedup([]int{1, 2, 3}))
+	require.Equal(t, []string{"b", "a", "c"}, Dedup([]string{"b", "a", "b", "c", "a", "b"}))
+	require.Equal(t, []int{7}, Dedup([]int{7, 7, 7}))
+
+	slice := []int{3, 1, 3}
+	Dedup(slice)
+	require.Equal(t, []int{3, 1, 3}, slice) // the input is not modified
+}
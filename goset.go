@@ -1,8 +1,13 @@
 package goset
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/amit7itz/goset/store"
+	"hash/fnv"
+	"iter"
+	"math/rand"
 	"reflect"
 	"strings"
 )
@@ -10,7 +15,8 @@ import (
 // Set represents a set data structure.
 // You should not call it directly, use NewSet() or FromSlice()
 type Set[T comparable] struct {
-	store store.SetStore[T]
+	store   store.SetStore[T]
+	version uint64
 }
 
 // NewSet returns a new Set of the given items
@@ -27,21 +33,197 @@ func FromSlice[T comparable](slice []T) *Set[T] {
 	return set
 }
 
+// NewSetFromStore returns a new Set that keeps its items in the given store, including any items already in it.
+// It lets custom SetStore implementations use all the Set methods
+func NewSetFromStore[T comparable](st store.SetStore[T]) *Set[T] {
+	return &Set[T]{store: st}
+}
+
+// FromSliceWithStore returns a new Set with all the items of the slice, kept in the given store.
+// The store should be empty, for example store.NewOrderedStore[T]()
+func FromSliceWithStore[T comparable](slice []T, st store.SetStore[T]) *Set[T] {
+	set := NewSetFromStore(st)
+	set.Add(slice...)
+	return set
+}
+
+// FromSliceWithCounts returns a new Set with all the items of the slice, and the number of times each item appears in it
+func FromSliceWithCounts[T comparable](slice []T) (*Set[T], map[T]int) {
+	counts := make(map[T]int)
+	for _, item := range slice {
+		counts[item]++
+	}
+	builder := NewSetBuilder[T](len(counts))
+	for item := range counts {
+		builder.Add(item)
+	}
+	return builder.Build(), counts
+}
+
+// FromBoolMap returns a new Set with all the keys of the map that are mapped to true
+func FromBoolMap[T comparable](m map[T]bool) *Set[T] {
+	set := NewSet[T]()
+	for item, ok := range m {
+		if ok {
+			set.Add(item)
+		}
+	}
+	return set
+}
+
+// NewCaseInsensitiveSet returns a new Set of strings that ignores case.
+// Items are stored lower-cased, so Items() and String() return the normalized form.
+// Only this Set ignores case: Copy() and the Sets returned by set operations (Union(), Intersection(), etc.)
+// are regular case-sensitive Sets of the lower-cased items. Methods that compare two Sets look the items up
+// with the Contains() of one of them, so mixing it with a case-sensitive Set can give asymmetric results
+// (e.g. NewCaseInsensitiveSet("a").Equal(NewSet("A")) is false but NewSet("A").Equal(NewCaseInsensitiveSet("a")) is true)
+func NewCaseInsensitiveSet(items ...string) *Set[string] {
+	set := &Set[string]{store: store.NewFoldedStringStore()}
+	set.Add(items...)
+	return set
+}
+
+// NewBoundedSet returns a new Set of the given items that holds at most maxSize items.
+// When a new item is added to a full Set, the oldest inserted item is evicted.
+// Re-adding an item that is already in the Set doesn't make it newer.
+// If maxSize is zero or negative, the Set stays empty and every added item is evicted right away
+func NewBoundedSet[T comparable](maxSize int, items ...T) *Set[T] {
+	set := &Set[T]{store: store.NewBoundedStore[T](maxSize)}
+	set.Add(items...)
+	return set
+}
+
+// NewRoundRobinSet returns a new Set of the given items whose Pop() returns the items in insertion order (FIFO),
+// which makes it usable as a deduplicating queue. Adding an item that is already in the Set doesn't move it
+func NewRoundRobinSet[T comparable](items ...T) *Set[T] {
+	set := &Set[T]{store: store.NewOrderedStore[T]()}
+	set.Add(items...)
+	return set
+}
+
 // Add adds item(s) to the Set
 func (s *Set[T]) Add(items ...T) {
+	firstNew := -1
+	for i, item := range items {
+		if !s.store.Contains(item) {
+			firstNew = i
+			break
+		}
+	}
+	before := s.store.Len()
 	s.store.Add(items...)
+	if firstNew < 0 {
+		return
+	}
+	if s.store.Len() != before {
+		s.version++
+		return
+	}
+	// the length didn't change, so the store evicted items to make room (e.g. a bounded Set).
+	// It changed only if it kept one of the new items (an item that was evicted and re-added by this call also counts)
+	for _, item := range items[firstNew:] {
+		if s.store.Contains(item) {
+			s.version++
+			return
+		}
+	}
+}
+
+// AddSlice adds all the items of the slice to the Set
+func (s *Set[T]) AddSlice(items []T) {
+	s.Add(items...)
 }
 
 // Remove removes a single item from the Set. Returns error if the item is not in the Set
 // See also: Discard()
 func (s *Set[T]) Remove(item T) error {
-	return s.store.Remove(item)
+	err := s.store.Remove(item)
+	if err == nil {
+		s.version++
+	}
+	return err
+}
+
+// RemoveMany removes the items from the Set. Items that are in the Set are removed even if others are not.
+// Returns the errors of all the items that are not in the Set joined together (see errors.Join()), or nil if all were removed
+func (s *Set[T]) RemoveMany(items ...T) error {
+	var errs []error
+	for _, item := range items {
+		if err := s.Remove(item); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Replace removes oldItem from the Set and adds newItem instead. Returns error (and adds nothing) if oldItem is not in the Set
+func (s *Set[T]) Replace(oldItem, newItem T) error {
+	if err := s.Remove(oldItem); err != nil {
+		return err
+	}
+	s.Add(newItem)
+	return nil
 }
 
 // Discard removes item(s) from the Set if exist
 // See also: Remove()
 func (s *Set[T]) Discard(items ...T) {
-	s.store.Discard(items...)
+	s.DiscardCount(items...)
+}
+
+// DiscardCount removes item(s) from the Set if exist, and returns the number of items that were actually removed
+func (s *Set[T]) DiscardCount(items ...T) int {
+	var count int
+	if counter, ok := s.store.(interface{ DiscardCount(items ...T) int }); ok {
+		count = counter.DiscardCount(items...)
+	} else {
+		before := s.store.Len()
+		s.store.Discard(items...)
+		count = before - s.store.Len()
+	}
+	if count > 0 {
+		s.version++
+	}
+	return count
+}
+
+// RemoveAll removes from the Set every item that is in the other Set, and returns the number of items that were removed
+func (s *Set[T]) RemoveAll(other *Set[T]) int {
+	if other.Len() <= s.Len() {
+		return s.DiscardCount(other.Items()...)
+	}
+	var common []T
+	s.store.For(func(item T) {
+		if other.Contains(item) {
+			common = append(common, item)
+		}
+	})
+	return s.DiscardCount(common...)
+}
+
+// Clear removes all the items from the Set
+func (s *Set[T]) Clear() {
+	if !s.store.IsEmpty() {
+		s.version++
+	}
+	if clearer, ok := s.store.(interface{ Clear() }); ok {
+		clearer.Clear()
+	} else {
+		s.store.Discard(s.store.Items()...)
+	}
+}
+
+// ShrinkToFit releases the memory left by items that were removed from the Set.
+// Go maps don't shrink, so it's worth calling after removing most of the items of a large Set
+func (s *Set[T]) ShrinkToFit() {
+	s.store.ShrinkToFit()
+}
+
+// Version returns a counter that increases whenever the Set items change.
+// Comparing it with a previously returned version tells whether the Set may have changed since then.
+// Operations that leave the items unchanged (like adding an existing item) don't increase it
+func (s *Set[T]) Version() uint64 {
+	return s.version
 }
 
 // Len returns the number of items in the Set
@@ -54,14 +236,98 @@ func (s *Set[T]) IsEmpty() bool {
 	return s.store.IsEmpty()
 }
 
+// IsSingleton returns true if there is exactly one item in the Set
+func (s *Set[T]) IsSingleton() bool {
+	return s.Len() == 1
+}
+
+// Single returns the only item of the Set, without removing it. Returns error if the Set doesn't have exactly one item
+func (s *Set[T]) Single() (T, error) {
+	var single T
+	if !s.IsSingleton() {
+		return single, fmt.Errorf("set has %d items, expected exactly one", s.Len())
+	}
+	s.store.ForWithBreak(func(item T) bool {
+		single = item
+		return false // stop iteration
+	})
+	return single, nil
+}
+
 // Contains returns whether an item is in the Set
 func (s *Set[T]) Contains(item T) bool {
 	return s.store.Contains(item)
 }
 
+// Get returns the item as it is stored in the Set, and whether it is in the Set.
+// It differs from item only in stores that normalize their items, e.g. NewCaseInsensitiveSet() returns the lower-cased form
+func (s *Set[T]) Get(item T) (T, bool) {
+	if getter, ok := s.store.(interface{ Get(item T) (T, bool) }); ok {
+		return getter.Get(item)
+	}
+	if s.Contains(item) {
+		return item, true
+	}
+	var zero T
+	return zero, false
+}
+
+// ContainsAllSlice returns whether all the items of the slice are in the Set
+func (s *Set[T]) ContainsAllSlice(items []T) bool {
+	for _, item := range items {
+		if !s.Contains(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// SliceIsSubset returns whether the items of the slice are a subset of the Set. It is the same as ContainsAllSlice()
+func (s *Set[T]) SliceIsSubset(items []T) bool {
+	return s.ContainsAllSlice(items)
+}
+
 // Pop removes an arbitrary item from the Set and returns it. Returns error if the Set is empty
 func (s *Set[T]) Pop() (T, error) {
-	return s.store.Pop()
+	item, err := s.store.Pop()
+	if err == nil {
+		s.version++
+	}
+	return item, err
+}
+
+// TryPop removes an arbitrary item from the Set and returns it, and whether the Set had any item to pop.
+// Unlike Pop(), it doesn't allocate an error when the Set is empty
+func (s *Set[T]) TryPop() (T, bool) {
+	if s.IsEmpty() {
+		var item T
+		return item, false
+	}
+	item, _ := s.store.Pop()
+	s.version++
+	return item, true
+}
+
+// PopRandom removes a uniformly random item from the Set and returns it. Returns error if the Set is empty
+// Unlike Pop() which is O(1), it is O(n) since it has to materialize the Set items
+func (s *Set[T]) PopRandom() (T, error) {
+	return s.popRandom(rand.Intn)
+}
+
+// PopRandomWithSource is like PopRandom() but uses the given random source
+func (s *Set[T]) PopRandomWithSource(r *rand.Rand) (T, error) {
+	return s.popRandom(r.Intn)
+}
+
+func (s *Set[T]) popRandom(intn func(n int) int) (T, error) {
+	if s.IsEmpty() {
+		var item T
+		return item, errors.New("set is empty")
+	}
+	items := s.Items()
+	item := items[intn(len(items))]
+	s.Discard(item)
+	return item, nil
 }
 
 // Items returns a slice of all the Set items
@@ -69,26 +335,156 @@ func (s *Set[T]) Items() []T {
 	return s.store.Items()
 }
 
+// AppendItems appends all the Set items to dst and returns the extended slice, like strconv.AppendInt().
+// It lets callers reuse a buffer instead of allocating a new slice in every Items() call
+func (s *Set[T]) AppendItems(dst []T) []T {
+	s.store.For(func(item T) {
+		dst = append(dst, item)
+	})
+	return dst
+}
+
+// ToMap returns a new map with the Set items as keys
+func (s *Set[T]) ToMap() map[T]struct{} {
+	m := make(map[T]struct{}, s.Len())
+	s.store.For(func(item T) {
+		m[item] = struct{}{}
+	})
+	return m
+}
+
+// ToBoolMap returns a new map with the Set items as keys, all mapped to true
+func (s *Set[T]) ToBoolMap() map[T]bool {
+	m := make(map[T]bool, s.Len())
+	s.store.For(func(item T) {
+		m[item] = true
+	})
+	return m
+}
+
 // For runs a function on all the items in the Set
 func (s *Set[T]) For(f func(item T)) {
 	s.store.For(f)
 }
 
+// All returns an iterator over all the items in the Set, to be used with a range loop
+func (s *Set[T]) All() iter.Seq[T] {
+	return s.store.Iter()
+}
+
 // ForWithBreak runs a function on all the items in the store
 // if f returns false, the iteration stops
 func (s *Set[T]) ForWithBreak(f func(item T) bool) {
 	s.store.ForWithBreak(f)
 }
 
+// Chunk splits the Set into new Sets of up to size items each, covering every item exactly once.
+// Since the Set is unordered, the way items are divided between the chunks is arbitrary.
+// Returns an empty slice if size is not positive
+func (s *Set[T]) Chunk(size int) []*Set[T] {
+	if size <= 0 {
+		return []*Set[T]{}
+	}
+	chunks := make([]*Set[T], 0, (s.Len()+size-1)/size)
+	var chunk *Set[T]
+	s.store.For(func(item T) {
+		if chunk == nil || chunk.Len() == size {
+			chunk = NewSet[T]()
+			chunks = append(chunks, chunk)
+		}
+		chunk.Add(item)
+	})
+	return chunks
+}
+
+// Tee runs all the consumers on every item in the Set, iterating the Set only once.
+// For each item, the consumers are called in the given order
+func (s *Set[T]) Tee(consumers ...func(item T)) {
+	s.store.For(func(item T) {
+		for _, consumer := range consumers {
+			consumer(item)
+		}
+	})
+}
+
+// Walk runs a function on all the items in the Set
+// if f returns an error, the iteration stops and the error is returned
+func (s *Set[T]) Walk(f func(item T) error) error {
+	var err error
+	s.store.ForWithBreak(func(item T) bool {
+		err = f(item)
+		return err == nil
+	})
+	return err
+}
+
+// SplitEvenly splits the Set into k new Sets whose sizes differ by at most one, covering every item exactly once.
+// Since the Set is unordered, the way items are divided between the Sets is arbitrary.
+// Returns an empty slice if k is not positive
+func (s *Set[T]) SplitEvenly(k int) []*Set[T] {
+	if k <= 0 {
+		return []*Set[T]{}
+	}
+	parts := make([]*Set[T], k)
+	for i := range parts {
+		parts[i] = NewSet[T]()
+	}
+	i := 0
+	s.store.For(func(item T) {
+		parts[i%k].Add(item)
+		i++
+	})
+	return parts
+}
+
+// ForEachChunk runs a function on batches of up to size items, covering every item exactly once.
+// The batch slice is reused between calls, so f must copy it if it needs the items after it returns.
+// if f returns an error, the iteration stops and the error is returned. Returns error if size is not positive
+func (s *Set[T]) ForEachChunk(size int, f func(batch []T) error) error {
+	if size <= 0 {
+		return fmt.Errorf("chunk size must be positive: %d", size)
+	}
+	batch := make([]T, 0, min(size, s.Len()))
+	var err error
+	s.store.ForWithBreak(func(item T) bool {
+		batch = append(batch, item)
+		if len(batch) == size {
+			err = f(batch)
+			batch = batch[:0]
+		}
+		return err == nil
+	})
+	if err == nil && len(batch) > 0 {
+		err = f(batch)
+	}
+	return err
+}
+
 // String returns a string that represents the Set
 func (s *Set[T]) String() string {
+	return s.StringN(s.Len())
+}
+
+// StringN returns a string that represents the Set with up to maxItems of its items, followed by the number of omitted items.
+// For example: Set[int]{1 2 3 ...(+999997 more)}
+func (s *Set[T]) StringN(maxItems int) string {
 	var t T
 	str := fmt.Sprintf("Set[%s]{", reflect.TypeOf(t).String())
-	itemsStr := make([]string, 0, s.Len())
-	s.store.For(func(item T) {
+	itemsStr := make([]string, 0, min(max(maxItems, 0), s.Len()))
+	s.store.ForWithBreak(func(item T) bool {
+		if len(itemsStr) >= maxItems {
+			return false // stop iteration
+		}
 		itemsStr = append(itemsStr, fmt.Sprintf("%v", item))
+		return true
 	})
 	str += strings.Join(itemsStr, " ")
+	if omitted := s.Len() - len(itemsStr); omitted > 0 {
+		if len(itemsStr) > 0 {
+			str += " "
+		}
+		str += fmt.Sprintf("...(+%d more)", omitted)
+	}
 	str += "}"
 	return str
 }
@@ -102,6 +498,15 @@ func (s *Set[T]) Update(others ...*Set[T]) {
 	}
 }
 
+// DrainTo moves all the items of the current Set to dst, leaving the current Set empty
+func (s *Set[T]) DrainTo(dst *Set[T]) {
+	if dst == s {
+		return
+	}
+	dst.Update(s)
+	s.Clear()
+}
+
 // Copy returns a new Set with the same items as the current Set
 func (s *Set[T]) Copy() *Set[T] {
 	set := NewSet[T]()
@@ -127,6 +532,56 @@ func (s *Set[T]) Equal(other *Set[T]) bool {
 	return equal
 }
 
+// EqualExcept returns true if the two Sets are equal after removing the items of ignore from both, without building any copies
+func (s *Set[T]) EqualExcept(other *Set[T], ignore *Set[T]) bool {
+	return s.subsetExcept(other, ignore) && other.subsetExcept(s, ignore)
+}
+
+// subsetExcept returns true if every item of the Set that isn't in ignore is in other
+func (s *Set[T]) subsetExcept(other *Set[T], ignore *Set[T]) bool {
+	subset := true
+	s.store.ForWithBreak(func(item T) bool {
+		subset = other.Contains(item) || ignore.Contains(item)
+		return subset
+	})
+	return subset
+}
+
+// EqualSlice returns whether the current Set contains exactly the distinct items of the slice, ignoring order and duplicates
+func (s *Set[T]) EqualSlice(items []T) bool {
+	if len(items) < s.Len() || !s.ContainsAllSlice(items) {
+		return false
+	}
+	// every item is in the Set, so it is equal if the slice covers all of its (stored) items
+	stored := make(map[T]struct{}, s.Len())
+	for _, item := range items {
+		storedItem, _ := s.Get(item)
+		stored[storedItem] = struct{}{}
+	}
+	return len(stored) == s.Len()
+}
+
+// EqualsUnionOf returns whether the current Set is exactly the union of the parts:
+// every part is a subset of the current Set, and every item of the current Set is in at least one of the parts
+func (s *Set[T]) EqualsUnionOf(parts ...*Set[T]) bool {
+	for _, part := range parts {
+		if !part.IsSubset(s) {
+			return false
+		}
+	}
+	covered := true
+	s.store.ForWithBreak(func(item T) bool {
+		for _, part := range parts {
+			if part.Contains(item) {
+				return true
+			}
+		}
+		covered = false
+		return false // stop iteration
+	})
+	return covered
+}
+
 // Union returns a new Set of the items from the current set and all others
 func (s *Set[T]) Union(others ...*Set[T]) *Set[T] {
 	unionSet := s.Copy()
@@ -134,22 +589,54 @@ func (s *Set[T]) Union(others ...*Set[T]) *Set[T] {
 	return unionSet
 }
 
+// UnionInto clears dst and fills it with the items from the current set and all others.
+// It lets hot loops reuse a destination Set instead of allocating a new one like Union() does.
+// dst may be the current set or one of the others
+func (s *Set[T]) UnionInto(dst *Set[T], others ...*Set[T]) {
+	dstIsInput := dst == s
+	for _, other := range others {
+		if dst == other {
+			dstIsInput = true
+		}
+	}
+	// when dst is one of the inputs its items are part of the union anyway, so it must not be cleared
+	if !dstIsInput {
+		dst.Clear()
+	}
+	dst.Update(s)
+	dst.Update(others...)
+}
+
 // Intersection returns a new Set with the common items of the current set and all others.
 func (s *Set[T]) Intersection(others ...*Set[T]) *Set[T] {
 	intersectionSet := NewSet[T]()
-	s.store.For(func(item T) {
-		inAllOthers := true
-		for _, other := range others {
-			if !other.Contains(item) {
-				inAllOthers = false
-				break
+	for item := range s.IntersectionSeq(others...) {
+		intersectionSet.Add(item)
+	}
+	return intersectionSet
+}
+
+// IntersectionSeq returns an iterator over the common items of the current set and all others.
+// The items are computed lazily, so breaking out of the iteration early skips the rest of the work
+func (s *Set[T]) IntersectionSeq(others ...*Set[T]) iter.Seq[T] {
+	return func(yield func(item T) bool) {
+		// iterate the smallest set and probe the rest of them
+		sets := append([]*Set[T]{s}, others...)
+		smallest := 0
+		for i, set := range sets {
+			if set.Len() < sets[smallest].Len() {
+				smallest = i
 			}
 		}
-		if inAllOthers {
-			intersectionSet.Add(item)
-		}
-	})
-	return intersectionSet
+		sets[smallest].store.ForWithBreak(func(item T) bool {
+			for i, set := range sets {
+				if i != smallest && !set.Contains(item) {
+					return true // not in all sets, continue to the next item
+				}
+			}
+			return yield(item)
+		})
+	}
 }
 
 // Difference returns a new Set of all the items in the current Set that are not in any of the others
@@ -170,32 +657,100 @@ func (s *Set[T]) Difference(others ...*Set[T]) *Set[T] {
 	return differenceSet
 }
 
-// SymmetricDifference returns all the items that exist in only one of the Sets
-func (s *Set[T]) SymmetricDifference(other *Set[T]) *Set[T] {
-	symmetricDifferenceSet := NewSet[T]()
+// Complement returns a new Set of the items of the universe that are not in the current Set.
+// Items of the current Set that are not in the universe are ignored
+func (s *Set[T]) Complement(universe *Set[T]) *Set[T] {
+	return universe.Difference(s)
+}
+
+// SymmetricDifference returns a new Set of the items that exist in an odd number of the Sets (the current and all others).
+// For two Sets, these are the items that exist in only one of them
+func (s *Set[T]) SymmetricDifference(others ...*Set[T]) *Set[T] {
+	symmetricDifferenceSet := s.Copy()
+	for _, other := range others {
+		other.store.For(func(item T) {
+			if symmetricDifferenceSet.Contains(item) {
+				symmetricDifferenceSet.Discard(item)
+			} else {
+				symmetricDifferenceSet.Add(item)
+			}
+		})
+	}
+	return symmetricDifferenceSet
+}
+
+// Compare returns three new Sets: the items only in the current Set, the items in both Sets, and the items only in the other Set.
+// It computes them with a single pass over each Set, instead of separate Difference() and Intersection() calls
+func (s *Set[T]) Compare(other *Set[T]) (onlyLeft, both, onlyRight *Set[T]) {
+	onlyLeft, both, onlyRight = NewSet[T](), NewSet[T](), NewSet[T]()
 	s.store.For(func(item T) {
-		if !other.Contains(item) {
-			symmetricDifferenceSet.Add(item)
+		if other.Contains(item) {
+			both.Add(item)
+		} else {
+			onlyLeft.Add(item)
 		}
 	})
 	other.store.For(func(item T) {
 		if !s.Contains(item) {
-			symmetricDifferenceSet.Add(item)
+			onlyRight.Add(item)
 		}
 	})
-	return symmetricDifferenceSet
+	return onlyLeft, both, onlyRight
+}
+
+// DiffersByAtMost returns whether at most n items exist in only one of the two Sets,
+// without building their SymmetricDifference(). It stops as soon as more than n such items are found
+func (s *Set[T]) DiffersByAtMost(other *Set[T], n int) bool {
+	if n < 0 || abs(s.Len()-other.Len()) > n {
+		return false
+	}
+	differences := 0
+	countMissing := func(from, in *Set[T]) {
+		from.store.ForWithBreak(func(item T) bool {
+			if !in.Contains(item) {
+				differences++
+			}
+			return differences <= n
+		})
+	}
+	countMissing(s, other)
+	if differences <= n {
+		countMissing(other, s)
+	}
+	return differences <= n
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
 }
 
 // IsDisjoint returns whether the two Sets have no item in common
 func (s *Set[T]) IsDisjoint(other *Set[T]) bool {
-	intersection := s.Intersection(other)
-	return intersection.IsEmpty()
+	disjoint := true
+	s.store.ForWithBreak(func(item T) bool {
+		if other.Contains(item) {
+			disjoint = false
+			return false // stop iteration
+		}
+		return true
+	})
+	return disjoint
 }
 
 // IsSubset returns whether all the items of the current set exist in the other one
 func (s *Set[T]) IsSubset(other *Set[T]) bool {
-	intersection := s.Intersection(other)
-	return intersection.Len() == s.Len()
+	subset := true
+	s.store.ForWithBreak(func(item T) bool {
+		if !other.Contains(item) {
+			subset = false
+			return false // stop iteration
+		}
+		return true
+	})
+	return subset
 }
 
 // IsSuperset returns whether all the items of the other set exist in the current one
@@ -203,13 +758,70 @@ func (s *Set[T]) IsSuperset(other *Set[T]) bool {
 	return other.IsSubset(s)
 }
 
+// IntersectionLen returns the number of items the two Sets have in common, without building their intersection
+func (s *Set[T]) IntersectionLen(other *Set[T]) int {
+	smaller, larger := s, other
+	if smaller.Len() > larger.Len() {
+		smaller, larger = larger, smaller
+	}
+	common := 0
+	smaller.store.For(func(item T) {
+		if larger.Contains(item) {
+			common++
+		}
+	})
+	return common
+}
+
+// Checksum returns a hash of the Set items that doesn't depend on the insertion or iteration order.
+// It XORs the FNV-1a hash of the fmt "%v" representation of every item, so equal Sets always have the same checksum.
+// Different Sets almost always have different checksums, but collisions are possible (like in any 64-bit hash),
+// and items with the same "%v" representation hash the same
+func (s *Set[T]) Checksum() uint64 {
+	var checksum uint64
+	h := fnv.New64a()
+	s.store.For(func(item T) {
+		h.Reset()
+		_, _ = fmt.Fprintf(h, "%v", item)
+		checksum ^= h.Sum64()
+	})
+	return checksum
+}
+
+// JaccardSimilarity returns the size of the intersection of the two Sets divided by the size of their union, a value in [0,1].
+// Two empty Sets are considered identical (1.0)
+func (s *Set[T]) JaccardSimilarity(other *Set[T]) float64 {
+	common := s.IntersectionLen(other)
+	union := s.Len() + other.Len() - common
+	if union == 0 {
+		return 1
+	}
+	return float64(common) / float64(union)
+}
+
+// DiceCoefficient returns twice the size of the intersection of the two Sets divided by the sum of their sizes, a value in [0,1].
+// Two empty Sets are considered identical (1.0)
+func (s *Set[T]) DiceCoefficient(other *Set[T]) float64 {
+	total := s.Len() + other.Len()
+	if total == 0 {
+		return 1
+	}
+	return float64(2*s.IntersectionLen(other)) / float64(total)
+}
+
 func (s *Set[T]) MarshalJSON() ([]byte, error) {
 	return s.store.MarshalJSON()
 }
 
 func (s *Set[T]) UnmarshalJSON(b []byte) error {
+	var items []T
+	err := json.Unmarshal(b, &items)
+	if err != nil {
+		return err
+	}
 	if s.store == nil {
 		s.store = store.NewSimpleStore[T]()
 	}
-	return s.store.UnmarshalJSON(b)
+	s.Add(items...)
+	return nil
 }
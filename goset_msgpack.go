@@ -0,0 +1,29 @@
+//go:build msgpack
+
+package goset
+
+import (
+	"github.com/amit7itz/goset/store"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// MarshalMsgpack returns the MessagePack encoding of the Set items as an array.
+// It is only available when building with the "msgpack" build tag
+func (s *Set[T]) MarshalMsgpack() ([]byte, error) {
+	return msgpack.Marshal(s.Items())
+}
+
+// UnmarshalMsgpack adds the items of a MessagePack encoded array to the Set.
+// It is only available when building with the "msgpack" build tag
+func (s *Set[T]) UnmarshalMsgpack(b []byte) error {
+	var items []T
+	err := msgpack.Unmarshal(b, &items)
+	if err != nil {
+		return err
+	}
+	if s.store == nil {
+		s.store = store.NewSimpleStore[T]()
+	}
+	s.Add(items...)
+	return nil
+}
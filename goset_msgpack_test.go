@@ -0,0 +1,41 @@
+//go:build msgpack
+
+package goset
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestSet_MarshalMsgpack(t *testing.T) {
+	s1 := NewSet[string]("a", "b", "c")
+	bytes, err := msgpack.Marshal(s1)
+	require.NoError(t, err)
+	s2 := NewSet[string]()
+	require.NoError(t, msgpack.Unmarshal(bytes, s2))
+	require.True(t, s1.Equal(s2))
+
+	var s3 *Set[string]
+	require.NoError(t, msgpack.Unmarshal(bytes, &s3))
+	require.True(t, s1.Equal(s3))
+}
+
+func BenchmarkSet_MarshalMsgpack(b *testing.B) {
+	s := NewSet[int]()
+	for i := 0; i < 10000; i++ {
+		s.Add(i)
+	}
+	jsonBytes, err := json.Marshal(s)
+	require.NoError(b, err)
+	b.ResetTimer()
+	var msgpackBytes []byte
+	for i := 0; i < b.N; i++ {
+		msgpackBytes, err = s.MarshalMsgpack()
+		require.NoError(b, err)
+	}
+	b.ReportMetric(float64(len(msgpackBytes)), "msgpack-bytes")
+	b.ReportMetric(float64(len(jsonBytes)), "json-bytes")
+}
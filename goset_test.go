@@ -2,12 +2,66 @@ package goset
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math/rand"
+	"runtime"
 	"testing"
 
+	"github.com/amit7itz/goset/store"
 	"github.com/stretchr/testify/require"
 )
 
+func TestSet_Version(t *testing.T) {
+	s := NewSet[string]()
+	v := s.Version()
+	s.Add("a", "b")
+	require.Greater(t, s.Version(), v)
+
+	v = s.Version()
+	s.Add("a")
+	s.Discard("c")
+	require.Error(t, s.Remove("c"))
+	s.ShrinkToFit()
+	require.Equal(t, v, s.Version())
+
+	require.NoError(t, s.Remove("a"))
+	require.Greater(t, s.Version(), v)
+	v = s.Version()
+	s.Discard("b", "c")
+	require.Greater(t, s.Version(), v)
+	v = s.Version()
+	s.Clear()
+	require.Equal(t, v, s.Version())
+
+	// evicting an item from a full bounded set is a change, even though the length stays the same
+	bounded := NewBoundedSet[int](2, 1, 2)
+	v = bounded.Version()
+	bounded.Add(3)
+	require.Greater(t, bounded.Version(), v)
+
+	// a zero-capacity bounded set evicts every added item, so it never changes
+	empty := NewBoundedSet[int](0)
+	v = empty.Version()
+	empty.Add(1, 2)
+	require.True(t, empty.IsEmpty())
+	require.Equal(t, v, empty.Version())
+
+	// unmarshaling only increases the version if it adds items
+	v = s.Version()
+	require.NoError(t, s.UnmarshalJSON([]byte(`[]`)))
+	require.Equal(t, v, s.Version())
+	require.NoError(t, s.UnmarshalJSON([]byte(`["a"]`)))
+	require.Greater(t, s.Version(), v)
+	v = s.Version()
+	require.NoError(t, s.UnmarshalJSON([]byte(`["a"]`)))
+	require.Error(t, s.UnmarshalJSON([]byte(`["b"`)))
+	require.Equal(t, v, s.Version())
+	v = bounded.Version()
+	require.NoError(t, bounded.UnmarshalJSON([]byte(`[4]`)))
+	require.Greater(t, bounded.Version(), v)
+}
+
 func TestSet_Len(t *testing.T) {
 	s := NewSet[string]()
 	require.Equal(t, s.Len(), 0)
@@ -17,6 +71,38 @@ func TestSet_Len(t *testing.T) {
 	require.Equal(t, s.Len(), 3)
 }
 
+func TestSet_AddSlice(t *testing.T) {
+	s := NewSet[string]("a")
+	s.AddSlice([]string{"a", "b", "c", "b"})
+	require.True(t, s.Equal(NewSet[string]("a", "b", "c")))
+	s.AddSlice(nil)
+	require.Equal(t, 3, s.Len())
+}
+
+func BenchmarkSet_Add(b *testing.B) {
+	items := make([]int, 10000)
+	for i := range items {
+		items[i] = i
+	}
+	s := NewSet[int]()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Add(items...)
+	}
+}
+
+func BenchmarkSet_AddSlice(b *testing.B) {
+	items := make([]int, 10000)
+	for i := range items {
+		items[i] = i
+	}
+	s := NewSet[int]()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.AddSlice(items)
+	}
+}
+
 func TestSet_IsEmpty(t *testing.T) {
 	s := NewSet[string]()
 	require.True(t, s.IsEmpty())
@@ -34,12 +120,80 @@ func BenchmarkSet_Items(b *testing.B) {
 	}
 }
 
+func BenchmarkSet_ItemsLarge(b *testing.B) {
+	s := RangeSet(0, 1000, 1)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Items()
+	}
+}
+
+func TestSet_Single(t *testing.T) {
+	s := NewSet[string]()
+	require.False(t, s.IsSingleton())
+	_, err := s.Single()
+	require.Error(t, err)
+
+	s.Add("a")
+	require.True(t, s.IsSingleton())
+	item, err := s.Single()
+	require.NoError(t, err)
+	require.Equal(t, "a", item)
+	require.Equal(t, 1, s.Len())
+
+	s.Add("b")
+	require.False(t, s.IsSingleton())
+	_, err = s.Single()
+	require.Error(t, err)
+}
+
 func TestSet_Items(t *testing.T) {
 	s1 := NewSet[string]("a", "b", "c")
 	s2 := FromSlice(s1.Items())
 	require.True(t, s1.Equal(s2))
 }
 
+func TestSet_ToMap(t *testing.T) {
+	s := NewSet[string]("a", "b")
+	m := s.ToMap()
+	require.Equal(t, map[string]struct{}{"a": {}, "b": {}}, m)
+	delete(m, "a")
+	require.True(t, s.Contains("a"))
+}
+
+func TestSet_ToBoolMap(t *testing.T) {
+	s := NewSet[string]("a", "b")
+	m := s.ToBoolMap()
+	require.Equal(t, map[string]bool{"a": true, "b": true}, m)
+	require.True(t, FromBoolMap(m).Equal(s))
+}
+
+func TestFromBoolMap(t *testing.T) {
+	s := FromBoolMap(map[string]bool{"a": true, "b": false, "c": true})
+	require.True(t, s.Equal(NewSet[string]("a", "c")))
+	require.True(t, FromBoolMap[string](nil).IsEmpty())
+}
+
+func TestSet_AppendItems(t *testing.T) {
+	s := NewSet[string]("a", "b", "c")
+	items := s.AppendItems([]string{"x"})
+	require.Equal(t, "x", items[0])
+	require.True(t, s.EqualSlice(items[1:]))
+	require.Len(t, items, 4)
+	require.Empty(t, NewSet[string]().AppendItems(nil))
+}
+
+func BenchmarkSet_AppendItems(b *testing.B) {
+	s := RangeSet(0, 1000, 1)
+	buf := make([]int, 0, s.Len())
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf = s.AppendItems(buf[:0])
+	}
+}
+
 func TestSet_For(t *testing.T) {
 	s1 := NewSet[string]("a", "b", "c")
 	s2 := NewSet[string]("a", "b", "c")
@@ -52,6 +206,41 @@ func TestSet_For(t *testing.T) {
 	require.True(t, s2.IsEmpty())
 }
 
+func TestSet_All(t *testing.T) {
+	s := NewSet[string]("a", "b", "c")
+	collected := NewSet[string]()
+	for item := range s.All() {
+		collected.Add(item)
+	}
+	require.True(t, collected.Equal(s))
+
+	counter := 0
+	for range s.All() {
+		counter++
+		if counter == 2 {
+			break
+		}
+	}
+	require.Equal(t, 2, counter)
+
+	ordered := FromSliceWithStore([]int{3, 1, 2}, store.NewOrderedStore[int]())
+	var items []int
+	for item := range ordered.All() {
+		items = append(items, item)
+		if item == 1 {
+			break
+		}
+	}
+	require.Equal(t, []int{3, 1}, items)
+
+	heap := NewHeapSet[int](3, 1, 2)
+	items = nil
+	for item := range heap.All() {
+		items = append(items, item)
+	}
+	require.ElementsMatch(t, []int{1, 2, 3}, items)
+}
+
 func TestSet_ForWithBreak(t *testing.T) {
 	s1 := NewSet[string]("a", "b", "c")
 	s2 := NewSet[string]("a", "b", "c")
@@ -68,6 +257,45 @@ func TestSet_ForWithBreak(t *testing.T) {
 	require.Equal(t, 1, s2.Len())
 }
 
+func TestSet_Tee(t *testing.T) {
+	s := NewSet[string]("a", "b", "c")
+	collected1 := NewSet[string]()
+	collected2 := NewSet[string]()
+	var order []int
+	s.Tee(func(item string) {
+		collected1.Add(item)
+		order = append(order, 1)
+	}, func(item string) {
+		collected2.Add(item)
+		order = append(order, 2)
+	})
+	require.True(t, collected1.Equal(s))
+	require.True(t, collected2.Equal(s))
+	require.Equal(t, []int{1, 2, 1, 2, 1, 2}, order)
+}
+
+func TestSet_Walk(t *testing.T) {
+	s := NewSet[string]("a", "b", "c", "d")
+	counter := 0
+	walkErr := errors.New("third item")
+	err := s.Walk(func(item string) error {
+		counter++
+		if counter == 3 {
+			return walkErr
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, walkErr)
+	require.Equal(t, 3, counter)
+
+	counter = 0
+	require.NoError(t, s.Walk(func(item string) error {
+		counter++
+		return nil
+	}))
+	require.Equal(t, 4, counter)
+}
+
 func BenchmarkFromSlice(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		FromSlice[string]([]string{"a", "b", "c"})
@@ -80,6 +308,113 @@ func TestFromSlice(t *testing.T) {
 	require.True(t, s1.Equal(s2))
 }
 
+// countingStore is a custom SetStore that counts the calls to Add
+type countingStore[T comparable] struct {
+	*store.SimpleSetStore[T]
+	adds int
+}
+
+func (s *countingStore[T]) Add(items ...T) {
+	s.adds++
+	s.SimpleSetStore.Add(items...)
+}
+
+func TestNewSetFromStore(t *testing.T) {
+	st := &countingStore[string]{SimpleSetStore: store.NewSimpleStore[string]()}
+	st.Add("a")
+	s := NewSetFromStore[string](st)
+	require.True(t, s.Contains("a"))
+	s.Add("b", "c")
+	require.Equal(t, 2, st.adds)
+	require.True(t, s.Union(NewSet[string]("d")).Equal(NewSet[string]("a", "b", "c", "d")))
+}
+
+// minimalStore is a custom SetStore that only has the methods of the SetStore interface
+type minimalStore[T comparable] struct {
+	store.SetStore[T]
+}
+
+func TestNewSetFromStore_MinimalStore(t *testing.T) {
+	s := NewSetFromStore[int](minimalStore[int]{store.NewSimpleStore[int]()})
+	s.Add(1, 2, 3)
+	version := s.Version()
+	require.Equal(t, 2, s.DiscardCount(1, 2, 4))
+	require.Greater(t, s.Version(), version)
+	require.Equal(t, []int{3}, s.Items())
+	version = s.Version()
+	require.Equal(t, 0, s.DiscardCount(1, 4))
+	require.Equal(t, version, s.Version())
+
+	s.Add(5, 6)
+	version = s.Version()
+	s.Clear()
+	require.True(t, s.IsEmpty())
+	require.Greater(t, s.Version(), version)
+	dst := NewSetFromStore[int](minimalStore[int]{store.NewSimpleStore[int]()})
+	dst.Add(7)
+	NewSet[int](1).UnionInto(dst, NewSet[int](2))
+	require.True(t, dst.Equal(NewSet[int](1, 2)))
+}
+
+func TestFromSliceWithStore(t *testing.T) {
+	s := FromSliceWithStore([]string{"c", "a", "b", "a"}, store.NewOrderedStore[string]())
+	require.Equal(t, []string{"c", "a", "b"}, s.Items())
+	s.Add("d", "c")
+	s.Discard("a")
+	require.Equal(t, []string{"c", "b", "d"}, s.Items())
+	item, err := s.Pop()
+	require.NoError(t, err)
+	require.Equal(t, "c", item)
+	require.Equal(t, "Set[string]{b d}", s.String())
+}
+
+func TestNewBoundedSet(t *testing.T) {
+	s := NewBoundedSet[int](3, 1, 2, 3, 4)
+	require.Equal(t, []int{2, 3, 4}, s.Items())
+	s.Add(2) // re-adding doesn't refresh the item
+	s.Add(5)
+	require.Equal(t, []int{3, 4, 5}, s.Items())
+	s.Add(6, 7, 8, 9)
+	require.Equal(t, 3, s.Len())
+	require.Equal(t, []int{7, 8, 9}, s.Items())
+	s.Discard(8)
+	s.Add(10)
+	require.Equal(t, []int{7, 9, 10}, s.Items())
+
+	require.True(t, NewBoundedSet[int](0, 1, 2).IsEmpty())
+	negative := NewBoundedSet[int](-1, 1, 2)
+	require.True(t, negative.IsEmpty())
+	negative.Add(3)
+	require.True(t, negative.IsEmpty())
+}
+
+func TestFromSliceWithCounts(t *testing.T) {
+	s, counts := FromSliceWithCounts([]string{"a", "b", "a", "c", "a", "b"})
+	require.True(t, s.Equal(NewSet[string]("a", "b", "c")))
+	require.Equal(t, map[string]int{"a": 3, "b": 2, "c": 1}, counts)
+
+	s, counts = FromSliceWithCounts[string](nil)
+	require.True(t, s.IsEmpty())
+	require.NotNil(t, counts)
+	require.Empty(t, counts)
+}
+
+func TestNewRoundRobinSet(t *testing.T) {
+	s := NewRoundRobinSet[string]("a", "b", "c")
+	s.Add("a", "d") // "a" is already queued and keeps its position
+	item, err := s.Pop()
+	require.NoError(t, err)
+	require.Equal(t, "a", item)
+	s.Add("a") // re-adding a popped item queues it again
+	var popped []string
+	for !s.IsEmpty() {
+		item, err = s.Pop()
+		require.NoError(t, err)
+		popped = append(popped, item)
+	}
+	require.Equal(t, []string{"b", "c", "d", "a"}, popped)
+}
+
 func TestSet_Union(t *testing.T) {
 	s1 := NewSet[string]("a")
 	s2 := NewSet[string]("b", "c")
@@ -92,6 +427,94 @@ func TestSet_Union(t *testing.T) {
 	require.True(t, union.Equal(NewSet[string]("a", "b", "c", "d", "e", "f")))
 }
 
+func BenchmarkSet_Union(b *testing.B) {
+	s1 := NewSet[int](1, 2, 3, 4, 5)
+	s2 := NewSet[int](4, 5, 6, 7, 8)
+	for i := 0; i < b.N; i++ {
+		s1.Union(s2)
+	}
+}
+
+func BenchmarkSet_UnionInto(b *testing.B) {
+	s1 := NewSet[int](1, 2, 3, 4, 5)
+	s2 := NewSet[int](4, 5, 6, 7, 8)
+	dst := NewSet[int]()
+	for i := 0; i < b.N; i++ {
+		s1.UnionInto(dst, s2)
+	}
+}
+
+func TestSet_UnionInto(t *testing.T) {
+	s1 := NewSet[string]("a")
+	s2 := NewSet[string]("b", "c")
+	s3 := NewSet[string]("c", "d")
+	dst := NewSet[string]("z")
+	s1.UnionInto(dst, s2, s3)
+	require.True(t, dst.Equal(NewSet[string]("a", "b", "c", "d")))
+	require.Equal(t, 1, s1.Len())
+
+	// dst is the receiver
+	s1.UnionInto(s1, s2)
+	require.True(t, s1.Equal(NewSet[string]("a", "b", "c")))
+
+	// dst is one of the others
+	s1.UnionInto(s3, s2, s3)
+	require.True(t, s3.Equal(NewSet[string]("a", "b", "c", "d")))
+}
+
+func TestSet_DiscardCount(t *testing.T) {
+	s := NewSet[string]("a", "b", "c")
+	require.Equal(t, 2, s.DiscardCount("a", "x", "c", "a"))
+	require.True(t, s.Equal(NewSet[string]("b")))
+	require.Equal(t, 0, s.DiscardCount("x"))
+
+	ci := NewCaseInsensitiveSet("A", "B")
+	require.Equal(t, 1, ci.DiscardCount("a", "C"))
+}
+
+func TestSet_RemoveAll(t *testing.T) {
+	s := NewSet[int](1, 2, 3, 4)
+	require.Equal(t, 2, s.RemoveAll(NewSet[int](3, 4, 5)))
+	require.True(t, s.Equal(NewSet[int](1, 2)))
+	require.Equal(t, 1, s.RemoveAll(RangeSet(2, 100, 1)))
+	require.True(t, s.Equal(NewSet[int](1)))
+	require.Equal(t, 0, s.RemoveAll(NewSet[int]()))
+	require.Equal(t, 1, s.RemoveAll(s))
+	require.True(t, s.IsEmpty())
+}
+
+func TestSet_Clear(t *testing.T) {
+	s := NewSet[string]("a", "b")
+	s.Clear()
+	require.True(t, s.IsEmpty())
+	s.Add("c")
+	require.Equal(t, 1, s.Len())
+}
+
+func heapAlloc() uint64 {
+	runtime.GC()
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return stats.HeapAlloc
+}
+
+func TestSet_ShrinkToFit(t *testing.T) {
+	s := RangeSet(0, 1000000, 1)
+	for i := 0; i < 900000; i++ {
+		s.Discard(i)
+	}
+	before := heapAlloc()
+	s.ShrinkToFit()
+	after := heapAlloc()
+	require.Less(t, after, before/2)
+	require.True(t, s.Equal(RangeSet(900000, 1000000, 1)))
+
+	ordered := FromSliceWithStore([]int{1, 2, 3}, store.NewOrderedStore[int]())
+	ordered.Discard(2)
+	ordered.ShrinkToFit()
+	require.Equal(t, []int{1, 3}, ordered.Items())
+}
+
 func TestSet_Equal(t *testing.T) {
 	s1 := NewSet[string]("a", "b")
 	s2 := NewSet[string]("b", "a")
@@ -104,6 +527,47 @@ func TestSet_Equal(t *testing.T) {
 	require.False(t, s3.Equal(s1))
 }
 
+func TestSet_EqualExcept(t *testing.T) {
+	noise := NewSet[string]("x", "y")
+	s1 := NewSet[string]("a", "b", "x")
+	require.True(t, s1.EqualExcept(NewSet[string]("a", "b"), noise))
+	require.True(t, s1.EqualExcept(NewSet[string]("b", "a", "y"), noise))
+	require.True(t, s1.EqualExcept(NewSet[string]("a", "b", "x", "y"), noise))
+	require.False(t, s1.EqualExcept(NewSet[string]("a", "b", "c"), noise))
+	require.False(t, s1.EqualExcept(NewSet[string]("a", "x"), noise))
+	require.False(t, s1.EqualExcept(NewSet[string]("a", "b"), NewSet[string]()))
+	require.True(t, s1.EqualExcept(s1, NewSet[string]()))
+	require.True(t, NewSet[string]("x").EqualExcept(NewSet[string]("y"), noise))
+}
+
+func TestSet_EqualSlice(t *testing.T) {
+	s := NewSet[string]("a", "b", "c")
+	require.True(t, s.EqualSlice([]string{"c", "a", "b"}))
+	require.True(t, s.EqualSlice([]string{"b", "a", "c", "a", "b"}))
+	require.False(t, s.EqualSlice([]string{"a", "b"}))
+	require.False(t, s.EqualSlice([]string{"a", "b", "b"}))
+	require.False(t, s.EqualSlice([]string{"a", "b", "c", "d"}))
+	require.True(t, NewSet[string]().EqualSlice(nil))
+
+	// the items are looked up like the Set's own Contains()
+	folded := NewCaseInsensitiveSet("a", "b")
+	require.True(t, folded.ContainsAllSlice([]string{"A"}))
+	require.True(t, folded.EqualSlice([]string{"A", "b"}))
+	require.True(t, folded.EqualSlice([]string{"A", "a", "B"}))
+	require.False(t, folded.EqualSlice([]string{"A", "a"}))
+	require.False(t, folded.EqualSlice([]string{"A", "b", "c"}))
+}
+
+func TestSet_EqualsUnionOf(t *testing.T) {
+	s := NewSet[int](1, 2, 3, 4)
+	require.True(t, s.EqualsUnionOf(NewSet[int](1, 2), NewSet[int](2, 3, 4)))
+	require.False(t, s.EqualsUnionOf(NewSet[int](1, 2), NewSet[int](4)))       // 3 isn't covered
+	require.False(t, s.EqualsUnionOf(NewSet[int](1, 2), NewSet[int](3, 4, 5))) // 5 isn't in s
+	require.False(t, s.EqualsUnionOf())
+	require.True(t, NewSet[int]().EqualsUnionOf())
+	require.True(t, NewSet[int]().EqualsUnionOf(NewSet[int]()))
+}
+
 func TestSet_Copy(t *testing.T) {
 	s1 := NewSet[string]("a", "b")
 	s2 := s1.Copy()
@@ -122,6 +586,17 @@ func TestSet_Update(t *testing.T) {
 	require.True(t, s2.Contains(3))
 }
 
+func TestSet_DrainTo(t *testing.T) {
+	s1 := NewSet[string]("a", "b")
+	s2 := NewSet[string]("b", "c")
+	s1.DrainTo(s2)
+	require.True(t, s1.IsEmpty())
+	require.True(t, s2.Equal(NewSet[string]("a", "b", "c")))
+
+	s2.DrainTo(s2)
+	require.Equal(t, 3, s2.Len())
+}
+
 func TestSet_Remove(t *testing.T) {
 	s := NewSet[int]()
 	s.Add(1)
@@ -129,6 +604,48 @@ func TestSet_Remove(t *testing.T) {
 	require.Error(t, s.Remove(1)) // should return error if item not found
 }
 
+func TestSet_RemoveMany(t *testing.T) {
+	s := NewSet[int](1, 2, 3, 4)
+	require.NoError(t, s.RemoveMany())
+	require.NoError(t, s.RemoveMany(1, 2))
+	require.True(t, s.Equal(NewSet[int](3, 4)))
+
+	version := s.Version()
+	err := s.RemoveMany(5, 3, 6)
+	require.Error(t, err)
+	require.ErrorContains(t, err, "item not found: 5")
+	require.ErrorContains(t, err, "item not found: 6")
+	require.NotContains(t, err.Error(), "item not found: 3")
+	require.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), 2)
+	require.True(t, s.Equal(NewSet[int](4)))
+	require.Greater(t, s.Version(), version)
+
+	version = s.Version()
+	require.Error(t, s.RemoveMany(7, 7))
+	require.Equal(t, version, s.Version())
+}
+
+func TestSet_Replace(t *testing.T) {
+	s := NewSet[string]("a", "b")
+	require.NoError(t, s.Replace("a", "c"))
+	require.True(t, s.Equal(NewSet[string]("b", "c")))
+	require.Error(t, s.Replace("a", "d"))
+	require.False(t, s.Contains("d"))
+	require.NoError(t, s.Replace("b", "b"))
+	require.NoError(t, s.Replace("b", "c"))
+	require.True(t, s.Equal(NewSet[string]("c")))
+}
+
+func TestSet_ContainsAllSlice(t *testing.T) {
+	allowed := NewSet[string]("id", "name", "email")
+	require.True(t, allowed.ContainsAllSlice([]string{"name", "id"}))
+	require.True(t, allowed.ContainsAllSlice([]string{"name", "name", "email"}))
+	require.True(t, allowed.ContainsAllSlice(nil))
+	require.False(t, allowed.ContainsAllSlice([]string{"name", "password", "name"}))
+	require.True(t, allowed.SliceIsSubset([]string{"email", "email"}))
+	require.False(t, allowed.SliceIsSubset([]string{"password"}))
+}
+
 func TestSet_Pop(t *testing.T) {
 	s := NewSet[string]()
 	s.Add("a")
@@ -141,12 +658,119 @@ func TestSet_Pop(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestSet_TryPop(t *testing.T) {
+	s := NewSet[string]("a")
+	item, ok := s.TryPop()
+	require.True(t, ok)
+	require.Equal(t, "a", item)
+	require.True(t, s.IsEmpty())
+	item, ok = s.TryPop()
+	require.False(t, ok)
+	require.Equal(t, "", item)
+}
+
+func BenchmarkSet_TryPopEmpty(b *testing.B) {
+	s := NewSet[string]()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s.TryPop()
+	}
+}
+
+func TestSet_PopRandom(t *testing.T) {
+	s := NewSet[string]("a", "b")
+	item, err := s.PopRandom()
+	require.NoError(t, err)
+	require.Contains(t, []string{"a", "b"}, item)
+	require.Equal(t, 1, s.Len())
+	require.False(t, s.Contains(item))
+	_, err = s.PopRandom()
+	require.NoError(t, err)
+	_, err = s.PopRandom()
+	require.Error(t, err)
+}
+
+func TestSet_PopRandomWithSource(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	const iterations = 40000
+	counts := map[int]int{}
+	for i := 0; i < iterations; i++ {
+		s := NewSet[int](0, 1, 2, 3)
+		item, err := s.PopRandomWithSource(r)
+		require.NoError(t, err)
+		require.Equal(t, 3, s.Len())
+		counts[item]++
+	}
+	expected := iterations / 4
+	for item := 0; item < 4; item++ {
+		require.InDelta(t, expected, counts[item], float64(expected)*0.05)
+	}
+}
+
+func BenchmarkSet_Intersection(b *testing.B) {
+	s1 := NewSet[int]()
+	for i := 0; i < 1000000; i++ {
+		s1.Add(i)
+	}
+	s2 := NewSet[int](1, 500000, -1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s1.Intersection(s2)
+	}
+}
+
 func TestSet_Intersection(t *testing.T) {
 	s1 := NewSet[string]("a", "b", "c", "d", "e", "f")
 	s2 := NewSet[string]("a", "", "c", "d", "e")
 	s3 := NewSet[string]("z", "d", "e", "k")
 	intersection := s1.Intersection(s2, s3)
 	require.True(t, intersection.Equal(NewSet[string]("e", "d")))
+	require.True(t, s3.Intersection(s1, s2).Equal(intersection))
+	require.True(t, s1.Intersection().Equal(s1))
+	require.True(t, s1.Intersection(s2, NewSet[string]()).IsEmpty())
+}
+
+func TestSet_IntersectionSeq(t *testing.T) {
+	s1 := RangeSet(0, 1000, 1)
+	s2 := RangeSet(0, 1000, 2)
+	s3 := RangeSet(0, 1000, 3)
+	result := NewSet[int]()
+	for item := range s1.IntersectionSeq(s2, s3) {
+		result.Add(item)
+	}
+	require.True(t, result.Equal(RangeSet(0, 1000, 6)))
+
+	// the iterator stops as soon as the consumer breaks (yielding after a break would panic)
+	consumed := 0
+	for item := range s1.IntersectionSeq(s2) {
+		require.Equal(t, 0, item%2)
+		consumed++
+		break
+	}
+	require.Equal(t, 1, consumed)
+
+	for range NewSet[int]().IntersectionSeq(s1) {
+		require.Fail(t, "intersection with an empty set should yield nothing")
+	}
+}
+
+func TestSet_IntersectionLen(t *testing.T) {
+	s1 := NewSet[string]("a", "b", "c", "d")
+	require.Equal(t, 2, s1.IntersectionLen(NewSet[string]("c", "d", "e")))
+	require.Equal(t, 4, s1.IntersectionLen(s1.Copy()))
+	require.Equal(t, 4, s1.IntersectionLen(NewSet[string]("a", "b", "c", "d", "e", "f")))
+	require.Equal(t, 0, s1.IntersectionLen(NewSet[string]()))
+	require.Equal(t, 0, NewSet[string]().IntersectionLen(s1))
+}
+
+func BenchmarkSet_IntersectionLen(b *testing.B) {
+	s1 := RangeSet(0, 1000, 1)
+	s2 := RangeSet(500, 1500, 1)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s1.IntersectionLen(s2)
+	}
 }
 
 func TestSet_Difference(t *testing.T) {
@@ -157,11 +781,57 @@ func TestSet_Difference(t *testing.T) {
 	require.True(t, difference.Equal(NewSet[string]("b", "f")))
 }
 
+func TestSet_Complement(t *testing.T) {
+	universe := NewSet[string]("a", "b", "c", "d")
+	enabled := NewSet[string]("a", "c", "x")
+	require.True(t, enabled.Complement(universe).Equal(NewSet[string]("b", "d")))
+	require.True(t, NewSet[string]().Complement(universe).Equal(universe))
+	require.True(t, universe.Complement(universe).IsEmpty())
+}
+
 func TestSet_SymmetricDifference(t *testing.T) {
 	s1 := NewSet[string]("a", "b", "c", "d", "e", "f")
 	s2 := NewSet[string]("z", "d", "e", "k")
 	difference := s1.SymmetricDifference(s2)
 	require.True(t, difference.Equal(NewSet[string]("a", "b", "c", "f", "z", "k")))
+	require.Equal(t, 6, s1.Len())
+
+	// with three sets, items in exactly one or in all three of them are kept
+	s3 := NewSet[string]("a", "z", "x")
+	difference = s1.SymmetricDifference(s2, s3)
+	require.True(t, difference.Equal(NewSet[string]("b", "c", "f", "k", "x")))
+	s4 := NewSet[string]("d", "q")
+	difference = s1.SymmetricDifference(s2, s4)
+	require.True(t, difference.Equal(NewSet[string]("a", "b", "c", "d", "f", "z", "k", "q")))
+	require.True(t, s1.SymmetricDifference().Equal(s1))
+}
+
+func TestSet_Compare(t *testing.T) {
+	s1 := NewSet[string]("a", "b", "c", "d")
+	s2 := NewSet[string]("c", "d", "e")
+	onlyLeft, both, onlyRight := s1.Compare(s2)
+	require.True(t, onlyLeft.Equal(NewSet[string]("a", "b")))
+	require.True(t, both.Equal(NewSet[string]("c", "d")))
+	require.True(t, onlyRight.Equal(NewSet[string]("e")))
+	require.True(t, IsPartition(s1.Union(s2), onlyLeft, both, onlyRight))
+
+	onlyLeft, both, onlyRight = s1.Compare(NewSet[string]())
+	require.True(t, onlyLeft.Equal(s1))
+	require.True(t, both.IsEmpty())
+	require.True(t, onlyRight.IsEmpty())
+}
+
+func TestSet_DiffersByAtMost(t *testing.T) {
+	s1 := NewSet[int](1, 2, 3, 4)
+	s2 := NewSet[int](2, 3, 4, 5, 6) // differs by 1, 5, 6
+	require.True(t, s1.DiffersByAtMost(s2, 3))
+	require.True(t, s1.DiffersByAtMost(s2, 4))
+	require.False(t, s1.DiffersByAtMost(s2, 2))
+	require.False(t, s2.DiffersByAtMost(s1, 2))
+	require.True(t, s1.DiffersByAtMost(s1.Copy(), 0))
+	require.False(t, s1.DiffersByAtMost(NewSet[int](), 3))
+	require.True(t, s1.DiffersByAtMost(NewSet[int](), 4))
+	require.False(t, s1.DiffersByAtMost(s1, -1))
 }
 
 func TestSet_IsSubset(t *testing.T) {
@@ -172,6 +842,41 @@ func TestSet_IsSubset(t *testing.T) {
 	require.True(t, s3.IsSubset(s1))
 	s4 := NewSet[string]()
 	require.True(t, s4.IsSubset(s1))
+	require.True(t, s4.IsSubset(NewSet[string]()))
+	require.False(t, s1.IsSubset(s4))
+	require.True(t, s1.IsSubset(s1))
+
+	// a larger Set can still be a subset of a Set that matches several of its items as one
+	require.True(t, NewSet[string]("A", "a").IsSubset(NewCaseInsensitiveSet("a")))
+	require.True(t, NewCaseInsensitiveSet("a").IsSuperset(NewSet[string]("A", "a")))
+}
+
+func BenchmarkSet_IsSubset(b *testing.B) {
+	s1 := NewSet[int]()
+	s2 := NewSet[int]()
+	for i := 0; i < 1000; i++ {
+		s1.Add(i)
+		s2.Add(i)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s1.IsSubset(s2)
+	}
+}
+
+func BenchmarkSet_IsDisjoint(b *testing.B) {
+	s1 := NewSet[int]()
+	s2 := NewSet[int]()
+	for i := 0; i < 1000; i++ {
+		s1.Add(i)
+		s2.Add(-i - 1)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s1.IsDisjoint(s2)
+	}
 }
 
 func TestSet_IsSuperset(t *testing.T) {
@@ -190,6 +895,106 @@ func TestSet_IsDisjoint(t *testing.T) {
 	require.False(t, s1.IsDisjoint(s2))
 	s3 := NewSet[string]("g", "h", "i")
 	require.True(t, s1.IsDisjoint(s3))
+	s4 := NewSet[string]()
+	require.True(t, s1.IsDisjoint(s4))
+	require.True(t, s4.IsDisjoint(s1))
+	require.True(t, s4.IsDisjoint(NewSet[string]()))
+}
+
+func TestSet_Chunk(t *testing.T) {
+	s := NewSet[int](1, 2, 3, 4, 5, 6, 7)
+	chunks := s.Chunk(3)
+	require.Len(t, chunks, 3)
+	covered := NewSet[int]()
+	total := 0
+	for _, chunk := range chunks {
+		require.LessOrEqual(t, chunk.Len(), 3)
+		require.True(t, covered.IsDisjoint(chunk))
+		covered.Update(chunk)
+		total += chunk.Len()
+	}
+	require.Equal(t, s.Len(), total)
+	require.True(t, covered.Equal(s))
+
+	require.Len(t, s.Chunk(7), 1)
+	require.Len(t, s.Chunk(100), 1)
+	require.Empty(t, s.Chunk(0))
+	require.Empty(t, s.Chunk(-1))
+	require.Empty(t, NewSet[int]().Chunk(3))
+}
+
+func TestSet_Checksum(t *testing.T) {
+	s1 := NewSet[string]("a", "b", "c")
+	s2 := FromSliceWithStore([]string{"c", "a", "b"}, store.NewOrderedStore[string]())
+	require.Equal(t, s1.Checksum(), s2.Checksum())
+	require.NotEqual(t, s1.Checksum(), NewSet[string]("a", "b").Checksum())
+	require.NotEqual(t, s1.Checksum(), NewSet[string]("a", "b", "d").Checksum())
+	require.NotEqual(t, NewSet[int](1, 2).Checksum(), NewSet[int](12).Checksum())
+	require.Equal(t, NewSet[int]().Checksum(), NewSet[int]().Checksum())
+}
+
+func TestSet_JaccardSimilarity(t *testing.T) {
+	s1 := NewSet[string]("a", "b", "c", "d")
+	require.Equal(t, 1.0, s1.JaccardSimilarity(NewSet[string]("d", "c", "b", "a")))
+	require.Equal(t, 0.0, s1.JaccardSimilarity(NewSet[string]("x", "y")))
+	require.Equal(t, 0.4, s1.JaccardSimilarity(NewSet[string]("c", "d", "e")))
+	require.Equal(t, 0.4, NewSet[string]("c", "d", "e").JaccardSimilarity(s1))
+	require.Equal(t, 0.0, s1.JaccardSimilarity(NewSet[string]()))
+	require.Equal(t, 1.0, NewSet[string]().JaccardSimilarity(NewSet[string]()))
+}
+
+func TestSet_DiceCoefficient(t *testing.T) {
+	s1 := NewSet[string]("a", "b", "c", "d")
+	require.Equal(t, 1.0, s1.DiceCoefficient(NewSet[string]("d", "c", "b", "a")))
+	require.Equal(t, 0.0, s1.DiceCoefficient(NewSet[string]("x", "y")))
+	require.InDelta(t, 4.0/7, s1.DiceCoefficient(NewSet[string]("c", "d", "e")), 1e-12)
+	require.InDelta(t, 4.0/7, NewSet[string]("c", "d", "e").DiceCoefficient(s1), 1e-12)
+	require.Equal(t, 0.0, s1.DiceCoefficient(NewSet[string]()))
+	require.Equal(t, 1.0, NewSet[string]().DiceCoefficient(NewSet[string]()))
+}
+
+func TestSet_SplitEvenly(t *testing.T) {
+	s := RangeSet(0, 10, 1)
+	parts := s.SplitEvenly(3)
+	require.Len(t, parts, 3)
+	sizes := ItemsFunc(NewSet(parts...), func(part *Set[int]) int { return part.Len() })
+	require.ElementsMatch(t, []int{4, 3, 3}, sizes)
+	require.True(t, IsPartition(s, parts...))
+
+	parts = NewSet[int](1, 2).SplitEvenly(4)
+	require.Len(t, parts, 4)
+	require.True(t, IsPartition(NewSet[int](1, 2), parts...))
+	require.Empty(t, s.SplitEvenly(0))
+	require.Empty(t, s.SplitEvenly(-2))
+}
+
+func TestSet_ForEachChunk(t *testing.T) {
+	s := RangeSet(0, 10, 1)
+	covered := NewSet[int]()
+	var sizes []int
+	err := s.ForEachChunk(4, func(batch []int) error {
+		sizes = append(sizes, len(batch))
+		covered.AddSlice(batch)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int{4, 4, 2}, sizes)
+	require.True(t, covered.Equal(s))
+
+	calls := 0
+	chunkErr := errors.New("db write failed")
+	err = s.ForEachChunk(3, func(batch []int) error {
+		calls++
+		return chunkErr
+	})
+	require.ErrorIs(t, err, chunkErr)
+	require.Equal(t, 1, calls)
+
+	require.Error(t, s.ForEachChunk(0, func(batch []int) error { return nil }))
+	require.NoError(t, NewSet[int]().ForEachChunk(3, func(batch []int) error {
+		require.Fail(t, "no batches expected for an empty set")
+		return nil
+	}))
 }
 
 func TestSet_String(t *testing.T) {
@@ -199,6 +1004,19 @@ func TestSet_String(t *testing.T) {
 	require.Contains(t, possibleOutputs, str)
 }
 
+func TestSet_StringN(t *testing.T) {
+	s := RangeSet(0, 1000000, 1)
+	str := s.StringN(3)
+	require.Regexp(t, `^Set\[int\]\{\d+ \d+ \d+ \.\.\.\(\+999997 more\)\}$`, str)
+	require.Equal(t, "Set[int]{...(+1000000 more)}", s.StringN(0))
+
+	small := NewSet[string]("a", "b")
+	possibleOutputs := []string{"Set[string]{a b}", "Set[string]{b a}"}
+	require.Contains(t, possibleOutputs, small.StringN(5))
+	require.Contains(t, possibleOutputs, small.StringN(2))
+	require.Equal(t, "Set[string]{}", NewSet[string]().StringN(3))
+}
+
 func TestSetWithStruct(t *testing.T) {
 	type Person struct {
 		Name string
@@ -231,3 +1049,47 @@ func TestSet_MarshalJSON(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, d.M["bla"].Equal(d2.M["bla"]))
 }
+
+func TestSet_Get(t *testing.T) {
+	s := NewSet[string]("a", "b")
+	item, ok := s.Get("a")
+	require.True(t, ok)
+	require.Equal(t, "a", item)
+	item, ok = s.Get("c")
+	require.False(t, ok)
+	require.Equal(t, "", item)
+
+	folded := NewCaseInsensitiveSet("Content-Type")
+	item, ok = folded.Get("CONTENT-TYPE")
+	require.True(t, ok)
+	require.Equal(t, "content-type", item)
+	_, ok = folded.Get("Accept")
+	require.False(t, ok)
+}
+
+func TestNewCaseInsensitiveSet(t *testing.T) {
+	s := NewCaseInsensitiveSet("Content-Type", "content-type", "CONTENT-TYPE")
+	require.Equal(t, 1, s.Len())
+	require.True(t, s.Contains("Content-Type"))
+	require.True(t, s.Contains("content-TYPE"))
+	require.Equal(t, []string{"content-type"}, s.Items())
+	s.Add("Accept")
+	require.Equal(t, 2, s.Len())
+	require.NoError(t, s.Remove("ACCEPT"))
+	s.Discard("CoNtEnT-tYpE")
+	require.True(t, s.IsEmpty())
+
+	// derived Sets are case-sensitive, with the lower-cased items
+	s = NewCaseInsensitiveSet("A", "b")
+	copied := s.Copy()
+	require.True(t, copied.Contains("a"))
+	require.False(t, copied.Contains("A"))
+	union := s.Union(NewSet[string]("C"))
+	require.True(t, union.Equal(NewSet[string]("a", "b", "C")))
+	require.False(t, union.Contains("c"))
+
+	// comparing with a case-sensitive Set is asymmetric
+	require.False(t, NewCaseInsensitiveSet("a").Equal(NewSet[string]("A")))
+	require.True(t, NewSet[string]("A").Equal(NewCaseInsensitiveSet("a")))
+	require.True(t, NewCaseInsensitiveSet("a").Equal(NewCaseInsensitiveSet("A")))
+}
@@ -0,0 +1,40 @@
+package goset
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+)
+
+// Join returns the items of the Set formatted with fmt.Sprint and joined with sep, in no particular order.
+// Items that contain sep are not escaped
+// See also: JoinSorted()
+func Join[T comparable](s *Set[T], sep string) string {
+	return join(s.Items(), sep)
+}
+
+// JoinSorted is like Join() but joins the items in sorted order, for a deterministic output (e.g. "a,b,c")
+func JoinSorted[T cmp.Ordered](s *Set[T], sep string) string {
+	return join(sortedItems(s), sep)
+}
+
+func join[T any](items []T, sep string) string {
+	parts := make([]string, len(items))
+	for i, item := range items {
+		parts[i] = fmt.Sprint(item)
+	}
+	return strings.Join(parts, sep)
+}
+
+// SplitToSet returns a new Set with the parts of s separated by sep, with surrounding whitespace trimmed.
+// Empty parts are dropped, so SplitToSet("a, b,,c,", ",") is {"a", "b", "c"}
+// See also: Join()
+func SplitToSet(s, sep string) *Set[string] {
+	set := NewSet[string]()
+	for _, part := range strings.Split(s, sep) {
+		if part = strings.TrimSpace(part); part != "" {
+			set.Add(part)
+		}
+	}
+	return set
+}
@@ -0,0 +1,34 @@
+package goset
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJoin(t *testing.T) {
+	require.Equal(t, "", Join(NewSet[int](), ","))
+	require.Equal(t, "7", Join(NewSet[int](7), ","))
+	parts := strings.Split(Join(NewSet[int](3, 1, 2), ", "), ", ")
+	require.ElementsMatch(t, []string{"1", "2", "3"}, parts)
+}
+
+func TestJoinSorted(t *testing.T) {
+	require.Equal(t, "", JoinSorted(NewSet[string](), ","))
+	require.Equal(t, "a,b,c", JoinSorted(NewSet[string]("c", "a", "b"), ","))
+	require.Equal(t, "-1 | 2 | 10", JoinSorted(NewSet[int](10, -1, 2), " | "))
+	// items containing the separator are not escaped
+	require.Equal(t, "a,b,c", JoinSorted(NewSet[string]("a,b", "c"), ","))
+}
+
+func TestSplitToSet(t *testing.T) {
+	require.True(t, SplitToSet("", ",").IsEmpty())
+	require.True(t, SplitToSet(" , ,", ",").IsEmpty())
+	require.True(t, SplitToSet("a,b,c", ",").Equal(NewSet[string]("a", "b", "c")))
+	require.True(t, SplitToSet("  a , b,,c,  ,a,", ",").Equal(NewSet[string]("a", "b", "c")))
+	require.True(t, SplitToSet("x | y", "|").Equal(NewSet[string]("x", "y")))
+
+	s := NewSet[string]("one", "two", "three")
+	require.True(t, SplitToSet(Join(s, ","), ",").Equal(s))
+}
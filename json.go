@@ -0,0 +1,53 @@
+package goset
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/amit7itz/goset/store"
+)
+
+// UnmarshalJSONStrict adds the items of a JSON array to the Set, like Set.UnmarshalJSON(),
+// but returns an error (and leaves the Set unchanged) if the array contains the same item more than once
+func UnmarshalJSONStrict[T comparable](b []byte, s *Set[T]) error {
+	var items []T
+	err := json.Unmarshal(b, &items)
+	if err != nil {
+		return err
+	}
+	seen := make(map[T]struct{}, len(items))
+	for _, item := range items {
+		if _, ok := seen[item]; ok {
+			return fmt.Errorf("duplicate item in JSON array: %v", item)
+		}
+		seen[item] = struct{}{}
+	}
+	if s.store == nil {
+		s.store = store.NewSimpleStore[T]()
+	}
+	s.Add(items...)
+	return nil
+}
+
+// UnmarshalJSONObject adds items to the Set from either a JSON array, like Set.UnmarshalJSON(),
+// or a JSON object whose keys are the items, e.g. {"a": true, "b": true}.
+// Every key of the object is added unless its value is false, so {"a": true, "b": false} adds only "a".
+// For the object form, T must be a string, an integer, or a type that implements encoding.TextUnmarshaler
+func UnmarshalJSONObject[T comparable](b []byte, s *Set[T]) error {
+	if s.store == nil {
+		s.store = store.NewSimpleStore[T]()
+	}
+	if trimmed := bytes.TrimSpace(b); len(trimmed) == 0 || trimmed[0] != '{' {
+		return s.UnmarshalJSON(b)
+	}
+	var object map[T]json.RawMessage
+	if err := json.Unmarshal(b, &object); err != nil {
+		return err
+	}
+	for item, value := range object {
+		if !bytes.Equal(bytes.TrimSpace(value), []byte("false")) {
+			s.Add(item)
+		}
+	}
+	return nil
+}
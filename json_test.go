@@ -0,0 +1,55 @@
+package goset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalJSONStrict(t *testing.T) {
+	s := NewSet[string]()
+	require.NoError(t, UnmarshalJSONStrict([]byte(`["a", "b", "c"]`), s))
+	require.True(t, s.Equal(NewSet[string]("a", "b", "c")))
+
+	s = NewSet[string]()
+	require.Error(t, UnmarshalJSONStrict([]byte(`["a", "b", "a"]`), s))
+	require.True(t, s.IsEmpty())
+	require.Error(t, UnmarshalJSONStrict([]byte(`{"a": 1}`), s))
+
+	// the default decoder stays lenient
+	require.NoError(t, s.UnmarshalJSON([]byte(`["a", "b", "a"]`)))
+	require.True(t, s.Equal(NewSet[string]("a", "b")))
+
+	var zero Set[int]
+	require.NoError(t, UnmarshalJSONStrict([]byte(`[1, 2]`), &zero))
+	require.Equal(t, 2, zero.Len())
+}
+
+func TestUnmarshalJSONObject(t *testing.T) {
+	s := NewSet[string]()
+	require.NoError(t, UnmarshalJSONObject([]byte(`["a", "b", "a"]`), s))
+	require.True(t, s.Equal(NewSet[string]("a", "b")))
+
+	s = NewSet[string]()
+	require.NoError(t, UnmarshalJSONObject([]byte(` {"a": true, "b": true, "c": false}`), s))
+	require.True(t, s.Equal(NewSet[string]("a", "b")))
+
+	s = NewSet[string]()
+	require.NoError(t, UnmarshalJSONObject([]byte(`{"a": 1, "b": {}, "c": null}`), s))
+	require.True(t, s.Equal(NewSet[string]("a", "b", "c")))
+
+	s = NewSet[string]()
+	require.NoError(t, UnmarshalJSONObject([]byte(`{}`), s))
+	require.True(t, s.IsEmpty())
+	require.Error(t, UnmarshalJSONObject([]byte(`{"a": true`), s))
+	require.Error(t, UnmarshalJSONObject([]byte(`"a"`), s))
+
+	var ints Set[int]
+	require.NoError(t, UnmarshalJSONObject([]byte(`{"1": true, "2": true, "3": false}`), &ints))
+	require.True(t, ints.Equal(NewSet[int](1, 2)))
+	require.Error(t, UnmarshalJSONObject([]byte(`{"x": true}`), &ints))
+
+	var floats Set[float64]
+	require.NoError(t, UnmarshalJSONObject([]byte(`[1.5, 2.5]`), &floats))
+	require.True(t, floats.Equal(NewSet[float64](1.5, 2.5)))
+}
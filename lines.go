@@ -0,0 +1,45 @@
+package goset
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"io"
+)
+
+// FromLines returns a new Set with the lines read from r, without their trailing "\n" or "\r\n".
+// Empty lines are skipped. Returns error if reading fails (including lines longer than bufio.MaxScanTokenSize)
+func FromLines(r io.Reader) (*Set[string], error) {
+	set := NewSet[string]()
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			set.Add(line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+// WriteLines writes each item of the Set to w, followed by a newline.
+// Returns the number of items written, and the first write error if any
+// See also: WriteSortedLines()
+func (s *Set[T]) WriteLines(w io.Writer) (int, error) {
+	return writeLines(w, s.Items())
+}
+
+// WriteSortedLines is like Set.WriteLines() but writes the items in sorted order, for a deterministic output
+func WriteSortedLines[T cmp.Ordered](s *Set[T], w io.Writer) (int, error) {
+	return writeLines(w, sortedItems(s))
+}
+
+func writeLines[T any](w io.Writer, items []T) (int, error) {
+	for i, item := range items {
+		if _, err := fmt.Fprintln(w, item); err != nil {
+			return i, err
+		}
+	}
+	return len(items), nil
+}
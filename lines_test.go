@@ -0,0 +1,62 @@
+package goset
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromLines(t *testing.T) {
+	s, err := FromLines(strings.NewReader("example.com\r\nfoo.org\n\nexample.com\n\r\nbar.net"))
+	require.NoError(t, err)
+	require.True(t, s.Equal(NewSet[string]("example.com", "foo.org", "bar.net")))
+
+	s, err = FromLines(strings.NewReader(""))
+	require.NoError(t, err)
+	require.True(t, s.IsEmpty())
+
+	_, err = FromLines(strings.NewReader(strings.Repeat("a", 100000)))
+	require.Error(t, err)
+}
+
+func TestSet_WriteLines(t *testing.T) {
+	s := NewSet[string]("a", "b")
+	var buf bytes.Buffer
+	n, err := s.WriteLines(&buf)
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	require.Contains(t, []string{"a\nb\n", "b\na\n"}, buf.String())
+
+	read, err := FromLines(&buf)
+	require.NoError(t, err)
+	require.True(t, read.Equal(s))
+}
+
+func TestWriteSortedLines(t *testing.T) {
+	var buf bytes.Buffer
+	n, err := WriteSortedLines(NewSet[int](3, 1, 2), &buf)
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+	require.Equal(t, "1\n2\n3\n", buf.String())
+}
+
+type failingWriter struct {
+	allowed int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.allowed == 0 {
+		return 0, errors.New("write failed")
+	}
+	w.allowed--
+	return len(p), nil
+}
+
+func TestWriteSortedLines_Error(t *testing.T) {
+	n, err := WriteSortedLines(NewSet[int](3, 1, 2), &failingWriter{allowed: 2})
+	require.Error(t, err)
+	require.Equal(t, 2, n)
+}
@@ -0,0 +1,87 @@
+package goset
+
+import (
+	"fmt"
+	"sort"
+)
+
+// MultiSet represents a set that also counts the occurrences of each item (also known as a Counter or a bag).
+// You should not call it directly, use NewMultiSet() or MultiSetFromSlice()
+type MultiSet[T comparable] struct {
+	counts map[T]int
+	total  int
+}
+
+// NewMultiSet returns a new MultiSet of the given items
+func NewMultiSet[T comparable](items ...T) *MultiSet[T] {
+	m := &MultiSet[T]{counts: make(map[T]int)}
+	m.Add(items...)
+	return m
+}
+
+// MultiSetFromSlice returns a new MultiSet with all the items of the slice.
+func MultiSetFromSlice[T comparable](slice []T) *MultiSet[T] {
+	return NewMultiSet[T](slice...)
+}
+
+// Add adds an occurrence of each of the item(s) to the MultiSet
+func (m *MultiSet[T]) Add(items ...T) {
+	for _, item := range items {
+		m.counts[item]++
+	}
+	m.total += len(items)
+}
+
+// Remove removes a single occurrence of an item from the MultiSet. Returns error if the item is not in the MultiSet
+// When the last occurrence is removed, the item is no longer in the MultiSet
+func (m *MultiSet[T]) Remove(item T) error {
+	count, ok := m.counts[item]
+	if !ok {
+		return fmt.Errorf("item not found: %v ", item)
+	}
+	if count == 1 {
+		delete(m.counts, item)
+	} else {
+		m.counts[item] = count - 1
+	}
+	m.total--
+	return nil
+}
+
+// Count returns the number of occurrences of an item in the MultiSet
+func (m *MultiSet[T]) Count(item T) int {
+	return m.counts[item]
+}
+
+// Total returns the number of occurrences of all the items in the MultiSet
+func (m *MultiSet[T]) Total() int {
+	return m.total
+}
+
+// Distinct returns a new Set of the distinct items in the MultiSet
+func (m *MultiSet[T]) Distinct() *Set[T] {
+	set := NewSet[T]()
+	for item := range m.counts {
+		set.Add(item)
+	}
+	return set
+}
+
+// MostCommon returns the n items with the most occurrences, from the most common to the least.
+// The order of items with the same count is arbitrary. If n is larger than the number of distinct items, all of them are returned
+func (m *MultiSet[T]) MostCommon(n int) []T {
+	items := make([]T, 0, len(m.counts))
+	for item := range m.counts {
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return m.counts[items[i]] > m.counts[items[j]]
+	})
+	if n < 0 {
+		n = 0
+	}
+	if n < len(items) {
+		items = items[:n]
+	}
+	return items
+}
@@ -0,0 +1,42 @@
+package goset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiSet_Add(t *testing.T) {
+	m := NewMultiSet[string]("a", "b", "a")
+	require.Equal(t, 2, m.Count("a"))
+	require.Equal(t, 1, m.Count("b"))
+	require.Equal(t, 0, m.Count("c"))
+	require.Equal(t, 3, m.Total())
+	m.Add("c", "a")
+	require.Equal(t, 3, m.Count("a"))
+	require.Equal(t, 5, m.Total())
+}
+
+func TestMultiSet_Remove(t *testing.T) {
+	m := NewMultiSet[string]("a", "a", "b")
+	require.NoError(t, m.Remove("a"))
+	require.Equal(t, 1, m.Count("a"))
+	require.NoError(t, m.Remove("a"))
+	require.Equal(t, 0, m.Count("a"))
+	require.False(t, m.Distinct().Contains("a")) // the key is deleted when the count reaches zero
+	require.Error(t, m.Remove("a"))
+	require.Equal(t, 1, m.Total())
+}
+
+func TestMultiSetFromSlice(t *testing.T) {
+	m := MultiSetFromSlice([]string{"to", "be", "or", "not", "to", "be"})
+	require.Equal(t, 6, m.Total())
+	require.True(t, m.Distinct().Equal(NewSet[string]("to", "be", "or", "not")))
+}
+
+func TestMultiSet_MostCommon(t *testing.T) {
+	m := NewMultiSet[string]("a", "b", "b", "c", "c", "c")
+	require.Equal(t, []string{"c", "b"}, m.MostCommon(2))
+	require.Equal(t, []string{"c", "b", "a"}, m.MostCommon(10))
+	require.Empty(t, m.MostCommon(0))
+}
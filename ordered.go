@@ -0,0 +1,116 @@
+package goset
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/amit7itz/goset/store"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// NewSeededSet returns a new Set of the given items that iterates them in a stable sorted order,
+// which makes runs reproducible (e.g. for fuzzing). The seed argument is ignored: every seed gives the same sorted order.
+// It is not the insertion order, and iterating costs O(n*log(n)). Pop() removes the smallest item
+func NewSeededSet[T cmp.Ordered](seed int64, items ...T) *Set[T] {
+	set := &Set[T]{store: store.NewSeededStore[T]()}
+	set.Add(items...)
+	return set
+}
+
+// sortedItems returns a sorted slice of all the Set items
+func sortedItems[T cmp.Ordered](s *Set[T]) []T {
+	items := s.Items()
+	slices.Sort(items)
+	return items
+}
+
+// NewHeapSet returns a new Set of the given items that keeps them in a binary min-heap.
+// Pop() and PopMin() remove the smallest item in O(log(n)), and Contains() stays O(1)
+func NewHeapSet[T cmp.Ordered](items ...T) *Set[T] {
+	set := &Set[T]{store: store.NewHeapStore[T]()}
+	set.Add(items...)
+	return set
+}
+
+// MarshalSortedJSON returns the JSON encoding of the Set as a sorted array.
+// Unlike Set.MarshalJSON, the output is stable for sets with the same items
+func MarshalSortedJSON[T cmp.Ordered](s *Set[T]) ([]byte, error) {
+	return json.Marshal(sortedItems(s))
+}
+
+// CanonicalKey returns a string that identifies the items of the Set regardless of the insertion order,
+// so it can be used as a map key for sets of sets.
+// Each sorted item is written as its length, a colon and the item itself (e.g. "1:a2:bc"),
+// so items that contain any separator character can't make two different Sets produce the same key
+func CanonicalKey[T cmp.Ordered](s *Set[T]) string {
+	var b strings.Builder
+	for _, item := range sortedItems(s) {
+		str := fmt.Sprint(item)
+		b.WriteString(strconv.Itoa(len(str)))
+		b.WriteByte(':')
+		b.WriteString(str)
+	}
+	return b.String()
+}
+
+// PopMin removes the smallest item from the Set and returns it. Returns error if the Set is empty
+// It is O(n) since it has to scan all the items, except for Sets created by NewHeapSet() where it is O(log(n))
+func PopMin[T cmp.Ordered](s *Set[T]) (T, error) {
+	if _, ok := s.store.(*store.HeapSetStore[T]); ok {
+		return s.Pop()
+	}
+	return popExtreme(s, func(a, b T) bool { return a < b })
+}
+
+// PopMax removes the largest item from the Set and returns it. Returns error if the Set is empty
+// It is O(n) since it has to scan all the items
+func PopMax[T cmp.Ordered](s *Set[T]) (T, error) {
+	return popExtreme(s, func(a, b T) bool { return a > b })
+}
+
+// popExtreme removes and returns the item that is before all others according to better
+func popExtreme[T cmp.Ordered](s *Set[T], better func(a, b T) bool) (T, error) {
+	var extreme T
+	if s.IsEmpty() {
+		return extreme, errors.New("set is empty")
+	}
+	first := true
+	s.For(func(item T) {
+		if first || better(item, extreme) {
+			extreme = item
+			first = false
+		}
+	})
+	s.Discard(extreme)
+	return extreme, nil
+}
+
+// SortedByKey returns a slice of the Set items, sorted in ascending order of the key extracted from each item.
+// The relative order of items with equal keys is arbitrary
+func SortedByKey[T comparable, K cmp.Ordered](s *Set[T], key func(item T) K) []T {
+	items := s.Items()
+	slices.SortFunc(items, func(a, b T) int {
+		return cmp.Compare(key(a), key(b))
+	})
+	return items
+}
+
+// Pair holds two values, as returned by Zip()
+type Pair[A any, B any] struct {
+	First  A
+	Second B
+}
+
+// Zip sorts the items of both Sets and pairs them by position.
+// If the Sets have different sizes, the result is truncated to the size of the smaller one
+func Zip[A cmp.Ordered, B cmp.Ordered](a *Set[A], b *Set[B]) []Pair[A, B] {
+	aItems, bItems := sortedItems(a), sortedItems(b)
+	pairs := make([]Pair[A, B], min(len(aItems), len(bItems)))
+	for i := range pairs {
+		pairs[i] = Pair[A, B]{First: aItems[i], Second: bItems[i]}
+	}
+	return pairs
+}
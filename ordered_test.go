@@ -0,0 +1,184 @@
+package goset
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalSortedJSON(t *testing.T) {
+	s1 := NewSet[int](5, 3, 10, -1, 3)
+	bytes, err := MarshalSortedJSON(s1)
+	require.NoError(t, err)
+	require.Equal(t, "[-1,3,5,10]", string(bytes))
+
+	s2 := NewSet[int]()
+	require.NoError(t, json.Unmarshal(bytes, s2))
+	require.True(t, s1.Equal(s2))
+
+	bytes, err = MarshalSortedJSON(NewSet[int]())
+	require.NoError(t, err)
+	require.Equal(t, "[]", string(bytes))
+}
+
+func TestNewSeededSet(t *testing.T) {
+	s1 := NewSeededSet[int](7, 5, 1, 4, 2, 3, 9, 8)
+	s2 := NewSeededSet[int](7, 8, 9, 3, 2, 4, 1, 5)
+	require.Equal(t, s1.Items(), s2.Items())
+	require.Equal(t, []int{1, 2, 3, 4, 5, 8, 9}, s1.Items())
+	require.Equal(t, s1.String(), s2.String())
+	require.Equal(t, s1.Items(), NewSeededSet[int](1, 9, 8, 5, 4, 3, 2, 1).Items()) // the seed is ignored
+
+	var iterated []int
+	s1.For(func(item int) {
+		iterated = append(iterated, item)
+	})
+	require.Equal(t, s1.Items(), iterated)
+
+	// the iterator sorts the items when the range loop starts, not when All() is called
+	all := s1.All()
+	s1.Add(0)
+	iterated = nil
+	for item := range all {
+		iterated = append(iterated, item)
+	}
+	require.Equal(t, []int{0, 1, 2, 3, 4, 5, 8, 9}, iterated)
+
+	item, err := s1.Pop()
+	require.NoError(t, err)
+	require.Equal(t, 0, item)
+	item, err = s1.Pop()
+	require.NoError(t, err)
+	require.Equal(t, 1, item)
+	require.Equal(t, []int{2, 3, 4, 5, 8, 9}, s1.Items())
+
+	_, err = NewSeededSet[int](1).Pop()
+	require.Error(t, err)
+}
+
+func TestCanonicalKey(t *testing.T) {
+	s1 := NewSet[string]("b", "a", "c")
+	s2 := NewSet[string]("c", "b", "a")
+	require.Equal(t, CanonicalKey(s1), CanonicalKey(s2))
+	require.Equal(t, "1:a1:b1:c", CanonicalKey(s1))
+
+	// separators inside the items don't cause collisions
+	require.NotEqual(t, CanonicalKey(NewSet[string]("a1:b")), CanonicalKey(NewSet[string]("a", "b")))
+	require.NotEqual(t, CanonicalKey(NewSet[string]("a,b")), CanonicalKey(NewSet[string]("a", "b")))
+	require.Equal(t, "", CanonicalKey(NewSet[int]()))
+
+	setOfSets := map[string]*Set[int]{}
+	setOfSets[CanonicalKey(NewSet[int](1, 2))] = NewSet[int](1, 2)
+	setOfSets[CanonicalKey(NewSet[int](2, 1))] = NewSet[int](2, 1)
+	require.Len(t, setOfSets, 1)
+}
+
+func TestPopMin(t *testing.T) {
+	s := NewSet[int](5, -3, 8, 0)
+	for _, expected := range []int{-3, 0, 5, 8} {
+		item, err := PopMin(s)
+		require.NoError(t, err)
+		require.Equal(t, expected, item)
+		require.False(t, s.Contains(item))
+	}
+	_, err := PopMin(s)
+	require.Error(t, err)
+}
+
+func TestPopMax(t *testing.T) {
+	s := NewSet[string]("b", "c", "a")
+	for _, expected := range []string{"c", "b", "a"} {
+		item, err := PopMax(s)
+		require.NoError(t, err)
+		require.Equal(t, expected, item)
+	}
+	_, err := PopMax(s)
+	require.Error(t, err)
+}
+
+func TestNewHeapSet(t *testing.T) {
+	s := NewHeapSet[int](5, 3, 9, 1, 7, 3)
+	require.Equal(t, 5, s.Len())
+	require.True(t, s.Contains(9))
+	require.NoError(t, s.Remove(3))
+	s.Discard(9, 100)
+	s.Add(4, 0)
+	require.True(t, s.Equal(NewSet[int](0, 1, 4, 5, 7)))
+
+	var popped []int
+	for !s.IsEmpty() {
+		item, err := PopMin(s)
+		require.NoError(t, err)
+		popped = append(popped, item)
+	}
+	require.Equal(t, []int{0, 1, 4, 5, 7}, popped)
+
+	s = NewHeapSet[int](RangeSet(0, 100, 1).Items()...)
+	for i := 0; i < 100; i += 3 {
+		s.Discard(i)
+	}
+	s.ShrinkToFit()
+	for i := 0; i < 100; i++ {
+		if i%3 == 0 {
+			continue
+		}
+		item, err := s.Pop()
+		require.NoError(t, err)
+		require.Equal(t, i, item)
+	}
+	_, err := s.Pop()
+	require.Error(t, err)
+
+	// an empty heap set marshals like the other stores
+	b, err := json.Marshal(NewHeapSet[int]())
+	require.NoError(t, err)
+	require.Equal(t, "[]", string(b))
+	s.Clear()
+	b, err = json.Marshal(s)
+	require.NoError(t, err)
+	require.Equal(t, "[]", string(b))
+}
+
+func BenchmarkPopMin(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		s := RangeSet(0, 1000, 1)
+		b.StartTimer()
+		for !s.IsEmpty() {
+			_, _ = PopMin(s)
+		}
+	}
+}
+
+func BenchmarkPopMin_HeapSet(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		s := NewHeapSet[int](RangeSet(0, 1000, 1).Items()...)
+		b.StartTimer()
+		for !s.IsEmpty() {
+			_, _ = PopMin(s)
+		}
+	}
+}
+
+func TestSortedByKey(t *testing.T) {
+	type Item struct {
+		ID   int
+		Name string
+	}
+	s := NewSet(Item{ID: 3, Name: "c"}, Item{ID: 1, Name: "a"}, Item{ID: 2, Name: "b"})
+	sorted := SortedByKey(s, func(item Item) int { return item.ID })
+	require.Equal(t, []Item{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}, {ID: 3, Name: "c"}}, sorted)
+	require.Empty(t, SortedByKey(NewSet[Item](), func(item Item) int { return item.ID }))
+}
+
+func TestZip(t *testing.T) {
+	require.Empty(t, Zip(NewSet[int](), NewSet[string]("a")))
+	require.Equal(t, []Pair[int, string]{{1, "a"}, {2, "b"}, {3, "c"}},
+		Zip(NewSet[int](3, 1, 2), NewSet[string]("b", "c", "a")))
+	require.Equal(t, []Pair[int, string]{{1, "x"}, {5, "y"}},
+		Zip(NewSet[int](5, 9, 1), NewSet[string]("y", "x")))
+	require.Equal(t, []Pair[float64, int]{{0.5, 10}},
+		Zip(NewSet[float64](0.5), NewSet[int](30, 10, 20)))
+}
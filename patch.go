@@ -0,0 +1,30 @@
+package goset
+
+// SetPatch holds the items to add to and remove from a Set to turn it into another Set.
+// It can be marshaled to JSON, e.g. to send the changes over the wire instead of the whole Set
+type SetPatch[T comparable] struct {
+	Added   []T `json:"added,omitempty"`
+	Removed []T `json:"removed,omitempty"`
+}
+
+// PatchTo returns the minimal SetPatch that turns the Set into target when passed to ApplyPatch()
+func (s *Set[T]) PatchTo(target *Set[T]) SetPatch[T] {
+	var patch SetPatch[T]
+	target.store.For(func(item T) {
+		if !s.Contains(item) {
+			patch.Added = append(patch.Added, item)
+		}
+	})
+	s.store.For(func(item T) {
+		if !target.Contains(item) {
+			patch.Removed = append(patch.Removed, item)
+		}
+	})
+	return patch
+}
+
+// ApplyPatch removes the Removed items of the patch from the Set, then adds its Added items
+func (s *Set[T]) ApplyPatch(p SetPatch[T]) {
+	s.Discard(p.Removed...)
+	s.Add(p.Added...)
+}
@@ -0,0 +1,47 @@
+package goset
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSet_PatchTo(t *testing.T) {
+	s := NewSet[int](1, 2, 3)
+	patch := s.PatchTo(NewSet[int](2, 3, 4, 5))
+	require.ElementsMatch(t, []int{4, 5}, patch.Added)
+	require.ElementsMatch(t, []int{1}, patch.Removed)
+
+	patch = s.PatchTo(s.Copy())
+	require.Empty(t, patch.Added)
+	require.Empty(t, patch.Removed)
+}
+
+func TestSet_ApplyPatch(t *testing.T) {
+	s := NewSet[string]("a", "b")
+	s.ApplyPatch(SetPatch[string]{Added: []string{"c"}, Removed: []string{"a", "x"}})
+	require.True(t, s.Equal(NewSet[string]("b", "c")))
+
+	version := s.Version()
+	s.ApplyPatch(SetPatch[string]{})
+	require.Equal(t, version, s.Version())
+}
+
+func TestSetPatch_JSONRoundTrip(t *testing.T) {
+	source := NewSet[string]("a", "b", "c")
+	target := NewSet[string]("b", "c", "d", "e")
+
+	b, err := json.Marshal(source.PatchTo(target))
+	require.NoError(t, err)
+	var patch SetPatch[string]
+	require.NoError(t, json.Unmarshal(b, &patch))
+
+	replica := source.Copy()
+	replica.ApplyPatch(patch)
+	require.True(t, replica.Equal(target))
+
+	b, err = json.Marshal(source.PatchTo(source))
+	require.NoError(t, err)
+	require.JSONEq(t, `{}`, string(b))
+}
@@ -0,0 +1,39 @@
+package goset
+
+import (
+	"github.com/amit7itz/goset/store"
+	"reflect"
+	"sync"
+)
+
+// setPools holds a *sync.Pool of released Sets for every item type
+var setPools sync.Map
+
+func setPool[T comparable]() *sync.Pool {
+	key := reflect.TypeFor[T]()
+	if pool, ok := setPools.Load(key); ok {
+		return pool.(*sync.Pool)
+	}
+	pool, _ := setPools.LoadOrStore(key, &sync.Pool{
+		New: func() any {
+			return NewSet[T]()
+		},
+	})
+	return pool.(*sync.Pool)
+}
+
+// AcquireSet returns an empty Set, reusing a previously released one if possible.
+// Use it together with ReleaseSet() for short-lived Sets, to reduce the GC pressure
+func AcquireSet[T comparable]() *Set[T] {
+	return setPool[T]().Get().(*Set[T])
+}
+
+// ReleaseSet clears the Set and returns it to the pool of AcquireSet().
+// The Set must not be used after it is released, since it may be handed to another caller at any moment
+func ReleaseSet[T comparable](s *Set[T]) {
+	if _, ok := s.store.(*store.SimpleSetStore[T]); !ok {
+		return // only plain Sets are pooled, so AcquireSet() always returns one
+	}
+	s.Clear()
+	setPool[T]().Put(s)
+}
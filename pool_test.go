@@ -0,0 +1,49 @@
+package goset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcquireSet(t *testing.T) {
+	s := AcquireSet[string]()
+	require.True(t, s.IsEmpty())
+	s.Add("a", "b")
+	ReleaseSet(s)
+
+	s = AcquireSet[string]()
+	require.True(t, s.IsEmpty())
+	s.Add("c")
+	require.True(t, s.Equal(NewSet[string]("c")))
+	ReleaseSet(s)
+
+	ints := AcquireSet[int]()
+	require.True(t, ints.IsEmpty())
+	ReleaseSet(ints)
+
+	// Sets with other stores are not pooled
+	ReleaseSet(NewBoundedSet[int](1, 5))
+	for i := 0; i < 10; i++ {
+		s := AcquireSet[int]()
+		s.Add(1, 2)
+		require.Equal(t, 2, s.Len())
+	}
+}
+
+func BenchmarkNewSet(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s := NewSet[string]()
+		s.Add("a", "b", "c")
+	}
+}
+
+func BenchmarkAcquireSet(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s := AcquireSet[string]()
+		s.Add("a", "b", "c")
+		ReleaseSet(s)
+	}
+}
@@ -0,0 +1,82 @@
+package goset
+
+// ReadOnlySet exposes only the non-mutating methods of a Set.
+// Use Set.ReadOnly() to get one
+type ReadOnlySet[T comparable] interface {
+	Len() int
+	IsEmpty() bool
+	Contains(item T) bool
+	Items() []T
+	For(f func(item T))
+	ForWithBreak(f func(item T) bool)
+	String() string
+	Copy() *Set[T]
+	Equal(other *Set[T]) bool
+	IsDisjoint(other *Set[T]) bool
+	IsSubset(other *Set[T]) bool
+	IsSuperset(other *Set[T]) bool
+	MarshalJSON() ([]byte, error)
+}
+
+// readOnlySet wraps a Set without embedding it, so the mutating methods can't be reached even by a type assertion
+type readOnlySet[T comparable] struct {
+	set *Set[T]
+}
+
+// ReadOnly returns a read-only view of the Set, backed by the same store (no copy is made).
+// Note that this is a view and not a snapshot: changes made to the Set by its owner are visible through the view
+func (s *Set[T]) ReadOnly() ReadOnlySet[T] {
+	return readOnlySet[T]{set: s}
+}
+
+func (r readOnlySet[T]) Len() int {
+	return r.set.Len()
+}
+
+func (r readOnlySet[T]) IsEmpty() bool {
+	return r.set.IsEmpty()
+}
+
+func (r readOnlySet[T]) Contains(item T) bool {
+	return r.set.Contains(item)
+}
+
+func (r readOnlySet[T]) Items() []T {
+	return r.set.Items()
+}
+
+func (r readOnlySet[T]) For(f func(item T)) {
+	r.set.For(f)
+}
+
+func (r readOnlySet[T]) ForWithBreak(f func(item T) bool) {
+	r.set.ForWithBreak(f)
+}
+
+func (r readOnlySet[T]) String() string {
+	return r.set.String()
+}
+
+func (r readOnlySet[T]) Copy() *Set[T] {
+	return r.set.Copy()
+}
+
+func (r readOnlySet[T]) Equal(other *Set[T]) bool {
+	return r.set.Equal(other)
+}
+
+func (r readOnlySet[T]) IsDisjoint(other *Set[T]) bool {
+	return r.set.IsDisjoint(other)
+}
+
+func (r readOnlySet[T]) IsSubset(other *Set[T]) bool {
+	return r.set.IsSubset(other)
+}
+
+func (r readOnlySet[T]) IsSuperset(other *Set[T]) bool {
+	return r.set.IsSuperset(other)
+}
+
+func (r readOnlySet[T]) MarshalJSON() ([]byte, error) {
+	return r.set.MarshalJSON()
+}
@@ -0,0 +1,26 @@
+package goset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSet_ReadOnly(t *testing.T) {
+	s := NewSet[string]("a", "b")
+	ro := s.ReadOnly()
+	require.Equal(t, 2, ro.Len())
+	require.True(t, ro.Contains("a"))
+	require.True(t, ro.Equal(NewSet[string]("a", "b")))
+
+	// the view is backed by the same store, so changes of the owner are visible
+	s.Add("c")
+	require.Equal(t, 3, ro.Len())
+	require.True(t, ro.Contains("c"))
+
+	// the view (and its dynamic type) has no mutating methods
+	_, canAdd := ro.(interface{ Add(items ...string) })
+	require.False(t, canAdd)
+	_, isSet := ro.(*Set[string])
+	require.False(t, isSet)
+}
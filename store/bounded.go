@@ -0,0 +1,42 @@
+package store
+
+import "encoding/json"
+
+// BoundedSetStore is a SetStore that holds at most maxSize items.
+// Adding a new item to a full store evicts the oldest inserted item.
+// Re-adding an item that is already in the store doesn't refresh its position.
+// A non-positive maxSize makes the store hold no items at all
+type BoundedSetStore[T comparable] struct {
+	*OrderedSetStore[T]
+	maxSize int
+}
+
+func NewBoundedStore[T comparable](maxSize int) *BoundedSetStore[T] {
+	maxSize = max(maxSize, 0)
+	return &BoundedSetStore[T]{
+		OrderedSetStore: NewOrderedStore[T](),
+		maxSize:         maxSize,
+	}
+}
+
+// Add adds item(s) to the store, evicting the oldest items if the store exceeds its max size
+func (s *BoundedSetStore[T]) Add(items ...T) {
+	for _, item := range items {
+		s.OrderedSetStore.Add(item)
+		for s.Len() > s.maxSize {
+			if _, err := s.Pop(); err != nil {
+				break
+			}
+		}
+	}
+}
+
+func (s *BoundedSetStore[T]) UnmarshalJSON(b []byte) error {
+	var items []T
+	err := json.Unmarshal(b, &items)
+	if err != nil {
+		return err
+	}
+	s.Add(items...)
+	return nil
+}
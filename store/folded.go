@@ -0,0 +1,67 @@
+package store
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// FoldedStringStore is a case-insensitive SetStore of strings.
+// Items are normalized with strings.ToLower when they are added, so Items() returns the lower-cased form
+type FoldedStringStore struct {
+	*SimpleSetStore[string]
+}
+
+func NewFoldedStringStore() *FoldedStringStore {
+	return &FoldedStringStore{
+		SimpleSetStore: NewSimpleStore[string](),
+	}
+}
+
+// Add adds item(s) to the store in their lower-cased form
+func (s *FoldedStringStore) Add(items ...string) {
+	for _, item := range items {
+		s.SimpleSetStore.Add(strings.ToLower(item))
+	}
+}
+
+// Remove removes a single item from the store, ignoring case. Returns error if the item is not in the Set
+// See also: Discard()
+func (s *FoldedStringStore) Remove(item string) error {
+	return s.SimpleSetStore.Remove(strings.ToLower(item))
+}
+
+// Discard removes item(s) from the store if exist, ignoring case
+// See also: Remove()
+func (s *FoldedStringStore) Discard(items ...string) {
+	for _, item := range items {
+		s.SimpleSetStore.Discard(strings.ToLower(item))
+	}
+}
+
+// DiscardCount removes item(s) from the store if exist, ignoring case, and returns the number of items that were removed
+func (s *FoldedStringStore) DiscardCount(items ...string) int {
+	before := s.Len()
+	s.Discard(items...)
+	return before - s.Len()
+}
+
+// Contains returns whether an item is in the store, ignoring case
+func (s *FoldedStringStore) Contains(item string) bool {
+	return s.SimpleSetStore.Contains(strings.ToLower(item))
+}
+
+// Get returns the stored (lower-cased) form of item, and whether it is in the store
+func (s *FoldedStringStore) Get(item string) (string, bool) {
+	folded := strings.ToLower(item)
+	return folded, s.SimpleSetStore.Contains(folded)
+}
+
+func (s *FoldedStringStore) UnmarshalJSON(b []byte) error {
+	var items []string
+	err := json.Unmarshal(b, &items)
+	if err != nil {
+		return err
+	}
+	s.Add(items...)
+	return nil
+}
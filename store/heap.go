@@ -0,0 +1,210 @@
+package store
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+)
+
+// HeapSetStore is a SetStore that keeps its items in a binary min-heap, alongside a map of their heap positions.
+// Pop removes the smallest item in O(log(n)), Add and Remove are O(log(n)) and Contains is O(1).
+// Iteration is in heap order, which is not sorted
+type HeapSetStore[T cmp.Ordered] struct {
+	heap  []T
+	index map[T]int
+}
+
+func NewHeapStore[T cmp.Ordered]() *HeapSetStore[T] {
+	return &HeapSetStore[T]{
+		index: make(map[T]int),
+	}
+}
+
+func (s *HeapSetStore[T]) swap(i, j int) {
+	s.heap[i], s.heap[j] = s.heap[j], s.heap[i]
+	s.index[s.heap[i]] = i
+	s.index[s.heap[j]] = j
+}
+
+func (s *HeapSetStore[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !(s.heap[i] < s.heap[parent]) {
+			break
+		}
+		s.swap(i, parent)
+		i = parent
+	}
+}
+
+func (s *HeapSetStore[T]) down(i int) {
+	n := len(s.heap)
+	for {
+		smallest := i
+		left, right := 2*i+1, 2*i+2
+		if left < n && s.heap[left] < s.heap[smallest] {
+			smallest = left
+		}
+		if right < n && s.heap[right] < s.heap[smallest] {
+			smallest = right
+		}
+		if smallest == i {
+			return
+		}
+		s.swap(i, smallest)
+		i = smallest
+	}
+}
+
+// removeAt removes the item at position i of the heap
+func (s *HeapSetStore[T]) removeAt(i int) {
+	last := len(s.heap) - 1
+	delete(s.index, s.heap[i])
+	if i != last {
+		s.heap[i] = s.heap[last]
+		s.index[s.heap[i]] = i
+	}
+	var zero T
+	s.heap[last] = zero
+	s.heap = s.heap[:last]
+	if i != last {
+		s.down(i)
+		s.up(i)
+	}
+}
+
+// Add adds item(s) to the store
+func (s *HeapSetStore[T]) Add(items ...T) {
+	for _, item := range items {
+		if _, ok := s.index[item]; ok {
+			continue
+		}
+		s.heap = append(s.heap, item)
+		s.index[item] = len(s.heap) - 1
+		s.up(len(s.heap) - 1)
+	}
+}
+
+// Remove removes a single item from the store. Returns error if the item is not in the Set
+// See also: Discard()
+func (s *HeapSetStore[T]) Remove(item T) error {
+	i, ok := s.index[item]
+	if !ok {
+		return fmt.Errorf("item not found: %v ", item)
+	}
+	s.removeAt(i)
+	return nil
+}
+
+// Discard removes item(s) from the store if exist
+// See also: Remove()
+func (s *HeapSetStore[T]) Discard(items ...T) {
+	for _, item := range items {
+		if i, ok := s.index[item]; ok {
+			s.removeAt(i)
+		}
+	}
+}
+
+// DiscardCount removes item(s) from the store if exist, and returns the number of items that were removed
+func (s *HeapSetStore[T]) DiscardCount(items ...T) int {
+	before := len(s.heap)
+	s.Discard(items...)
+	return before - len(s.heap)
+}
+
+// Clear removes all the items from the store
+func (s *HeapSetStore[T]) Clear() {
+	clear(s.index)
+	clear(s.heap)
+	s.heap = s.heap[:0]
+}
+
+// ShrinkToFit rebuilds the backing map and slice at their current size, releasing the memory left by deleted items
+func (s *HeapSetStore[T]) ShrinkToFit() {
+	heap := make([]T, len(s.heap))
+	copy(heap, s.heap)
+	index := make(map[T]int, len(s.index))
+	for item, i := range s.index {
+		index[item] = i
+	}
+	s.heap, s.index = heap, index
+}
+
+// Len returns the number of items in the store
+func (s *HeapSetStore[T]) Len() int {
+	return len(s.heap)
+}
+
+// IsEmpty returns true if there are no items in the store
+func (s *HeapSetStore[T]) IsEmpty() bool {
+	return len(s.heap) == 0
+}
+
+// Contains returns whether an item is in the store
+func (s *HeapSetStore[T]) Contains(item T) bool {
+	_, ok := s.index[item]
+	return ok
+}
+
+// Pop removes the smallest item from the store and returns it. Returns error if the store is empty
+func (s *HeapSetStore[T]) Pop() (T, error) {
+	var item T
+	if s.IsEmpty() {
+		return item, errors.New("set is empty")
+	}
+	item = s.heap[0]
+	s.removeAt(0)
+	return item, nil
+}
+
+// Items returns a slice of all the Set items in heap order
+func (s *HeapSetStore[T]) Items() []T {
+	items := make([]T, len(s.heap))
+	copy(items, s.heap)
+	return items
+}
+
+// For runs a function on all the items in the store
+func (s *HeapSetStore[T]) For(f func(item T)) {
+	for _, item := range s.Items() { // f may remove items, which reorders the heap
+		f(item)
+	}
+}
+
+// ForWithBreak runs a function on all the items in the store
+// if f returns false, the iteration stops
+func (s *HeapSetStore[T]) ForWithBreak(f func(item T) bool) {
+	for _, item := range s.Items() { // f may remove items, which reorders the heap
+		if !f(item) {
+			break
+		}
+	}
+}
+
+// Iter returns an iterator over all the items in the store
+func (s *HeapSetStore[T]) Iter() iter.Seq[T] {
+	return func(yield func(item T) bool) {
+		for _, item := range s.Items() { // the loop body may remove items, which reorders the heap
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+func (s *HeapSetStore[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Items())
+}
+
+func (s *HeapSetStore[T]) UnmarshalJSON(b []byte) error {
+	var items []T
+	err := json.Unmarshal(b, &items)
+	if err != nil {
+		return err
+	}
+	s.Add(items...)
+	return nil
+}
@@ -0,0 +1,200 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+)
+
+type orderedNode[T comparable] struct {
+	item       T
+	prev, next *orderedNode[T]
+}
+
+// OrderedSetStore is a SetStore that keeps its items in insertion order.
+// Adding an item that is already in the store doesn't change its position.
+// Pop removes the oldest item
+type OrderedSetStore[T comparable] struct {
+	nodes      map[T]*orderedNode[T]
+	head, tail *orderedNode[T]
+}
+
+func NewOrderedStore[T comparable]() *OrderedSetStore[T] {
+	return &OrderedSetStore[T]{
+		nodes: make(map[T]*orderedNode[T]),
+	}
+}
+
+// Add adds item(s) to the end of the store
+func (s *OrderedSetStore[T]) Add(items ...T) {
+	for _, item := range items {
+		if _, ok := s.nodes[item]; ok {
+			continue
+		}
+		node := &orderedNode[T]{item: item, prev: s.tail}
+		if s.tail == nil {
+			s.head = node
+		} else {
+			s.tail.next = node
+		}
+		s.tail = node
+		s.nodes[item] = node
+	}
+}
+
+func (s *OrderedSetStore[T]) unlink(node *orderedNode[T]) {
+	if node.prev == nil {
+		s.head = node.next
+	} else {
+		node.prev.next = node.next
+	}
+	if node.next == nil {
+		s.tail = node.prev
+	} else {
+		node.next.prev = node.prev
+	}
+	delete(s.nodes, node.item)
+}
+
+// Remove removes a single item from the store. Returns error if the item is not in the Set
+// See also: Discard()
+func (s *OrderedSetStore[T]) Remove(item T) error {
+	node, ok := s.nodes[item]
+	if !ok {
+		return fmt.Errorf("item not found: %v ", item)
+	}
+	s.unlink(node)
+	return nil
+}
+
+// Discard removes item(s) from the store if exist
+// See also: Remove()
+func (s *OrderedSetStore[T]) Discard(items ...T) {
+	for _, item := range items {
+		if node, ok := s.nodes[item]; ok {
+			s.unlink(node)
+		}
+	}
+}
+
+// DiscardCount removes item(s) from the store if exist, and returns the number of items that were removed
+func (s *OrderedSetStore[T]) DiscardCount(items ...T) int {
+	before := len(s.nodes)
+	s.Discard(items...)
+	return before - len(s.nodes)
+}
+
+// TrimBefore removes all the items that were inserted before the given item, and returns the number of items that were removed.
+// Returns 0 if the item is not in the store
+func (s *OrderedSetStore[T]) TrimBefore(item T) int {
+	node, ok := s.nodes[item]
+	if !ok {
+		return 0
+	}
+	count := 0
+	for s.head != node {
+		s.unlink(s.head)
+		count++
+	}
+	return count
+}
+
+// Clear removes all the items from the store
+func (s *OrderedSetStore[T]) Clear() {
+	clear(s.nodes)
+	s.head, s.tail = nil, nil
+}
+
+// ShrinkToFit rebuilds the backing map at its current size, releasing the memory left by deleted items
+func (s *OrderedSetStore[T]) ShrinkToFit() {
+	nodes := make(map[T]*orderedNode[T], len(s.nodes))
+	for item, node := range s.nodes {
+		nodes[item] = node
+	}
+	s.nodes = nodes
+}
+
+// Len returns the number of items in the store
+func (s *OrderedSetStore[T]) Len() int {
+	return len(s.nodes)
+}
+
+// IsEmpty returns true if there are no items in the store
+func (s *OrderedSetStore[T]) IsEmpty() bool {
+	return len(s.nodes) == 0
+}
+
+// Contains returns whether an item is in the store
+func (s *OrderedSetStore[T]) Contains(item T) bool {
+	_, ok := s.nodes[item]
+	return ok
+}
+
+// Pop removes the oldest item from the store and returns it. Returns error if the store is empty
+func (s *OrderedSetStore[T]) Pop() (T, error) {
+	var item T
+	if s.IsEmpty() {
+		return item, errors.New("set is empty")
+	}
+	item = s.head.item
+	s.unlink(s.head)
+	return item, nil
+}
+
+// Items returns a slice of all the Set items in insertion order
+func (s *OrderedSetStore[T]) Items() []T {
+	items := make([]T, 0, s.Len())
+	for node := s.head; node != nil; node = node.next {
+		items = append(items, node.item)
+	}
+	return items
+}
+
+// For runs a function on all the items in the store in insertion order
+func (s *OrderedSetStore[T]) For(f func(item T)) {
+	for node := s.head; node != nil; {
+		next := node.next // f may remove the current item
+		f(node.item)
+		node = next
+	}
+}
+
+// ForWithBreak runs a function on all the items in the store in insertion order
+// if f returns false, the iteration stops
+func (s *OrderedSetStore[T]) ForWithBreak(f func(item T) bool) {
+	for node := s.head; node != nil; {
+		next := node.next // f may remove the current item
+		if !f(node.item) {
+			break
+		}
+		node = next
+	}
+}
+
+// Iter returns an iterator over all the items in the store in insertion order
+func (s *OrderedSetStore[T]) Iter() iter.Seq[T] {
+	return func(yield func(item T) bool) {
+		for node := s.head; node != nil; {
+			next := node.next // the loop body may remove the current item
+			if !yield(node.item) {
+				return
+			}
+			node = next
+		}
+	}
+}
+
+func (s *OrderedSetStore[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Items())
+}
+
+func (s *OrderedSetStore[T]) UnmarshalJSON(b []byte) error {
+	var items []T
+	err := json.Unmarshal(b, &items)
+	if err != nil {
+		return err
+	}
+	s.Add(items...)
+	return nil
+}
@@ -0,0 +1,74 @@
+package store
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"iter"
+	"slices"
+)
+
+// SeededSetStore is a SetStore that iterates its items in a deterministic order: the sorted order.
+// It is meant for reproducibility and does not reflect the insertion order.
+// Each iteration sorts the items, so it costs O(n*log(n))
+type SeededSetStore[T cmp.Ordered] struct {
+	*SimpleSetStore[T]
+}
+
+func NewSeededStore[T cmp.Ordered]() *SeededSetStore[T] {
+	return &SeededSetStore[T]{
+		SimpleSetStore: NewSimpleStore[T](),
+	}
+}
+
+// Pop removes the smallest item (the first in the store order) and returns it, in O(n). Returns error if the store is empty
+func (s *SeededSetStore[T]) Pop() (T, error) {
+	var smallest T
+	if s.IsEmpty() {
+		return smallest, errors.New("set is empty")
+	}
+	first := true
+	for item := range s.store {
+		if first || cmp.Less(item, smallest) {
+			smallest, first = item, false
+		}
+	}
+	s.Discard(smallest)
+	return smallest, nil
+}
+
+// Items returns a slice of all the Set items in the store order
+func (s *SeededSetStore[T]) Items() []T {
+	items := s.SimpleSetStore.Items()
+	slices.Sort(items)
+	return items
+}
+
+// For runs a function on all the items in the store, in the store order
+func (s *SeededSetStore[T]) For(f func(item T)) {
+	for _, item := range s.Items() {
+		f(item)
+	}
+}
+
+// ForWithBreak runs a function on all the items in the store, in the store order
+// if f returns false, the iteration stops
+func (s *SeededSetStore[T]) ForWithBreak(f func(item T) bool) {
+	for _, item := range s.Items() {
+		if !f(item) {
+			break
+		}
+	}
+}
+
+// Iter returns an iterator over all the items in the store, in the store order.
+// The items are sorted when the iteration starts
+func (s *SeededSetStore[T]) Iter() iter.Seq[T] {
+	return func(yield func(item T) bool) {
+		s.ForWithBreak(yield)
+	}
+}
+
+func (s *SeededSetStore[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Items())
+}
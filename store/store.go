@@ -4,12 +4,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"iter"
 )
 
 type SetStore[T comparable] interface {
 	Add(items ...T)
 	Remove(item T) error
 	Discard(items ...T)
+	ShrinkToFit()
 	Len() int
 	IsEmpty() bool
 	Contains(item T) bool
@@ -17,6 +19,7 @@ type SetStore[T comparable] interface {
 	Items() []T
 	For(func(item T))
 	ForWithBreak(func(item T) bool)
+	Iter() iter.Seq[T]
 	MarshalJSON() ([]byte, error)
 	UnmarshalJSON(b []byte) error
 }
@@ -31,6 +34,13 @@ func NewSimpleStore[T comparable]() *SimpleSetStore[T] {
 	}
 }
 
+// NewSimpleStoreWithCapacity returns a SimpleSetStore with room for at least capacity items
+func NewSimpleStoreWithCapacity[T comparable](capacity int) *SimpleSetStore[T] {
+	return &SimpleSetStore[T]{
+		store: make(map[T]struct{}, capacity),
+	}
+}
+
 // Add adds item(s) to the store
 func (s *SimpleSetStore[T]) Add(items ...T) {
 	for _, item := range items {
@@ -56,6 +66,27 @@ func (s *SimpleSetStore[T]) Discard(items ...T) {
 	}
 }
 
+// DiscardCount removes item(s) from the store if exist, and returns the number of items that were removed
+func (s *SimpleSetStore[T]) DiscardCount(items ...T) int {
+	before := len(s.store)
+	s.Discard(items...)
+	return before - len(s.store)
+}
+
+// Clear removes all the items from the store
+func (s *SimpleSetStore[T]) Clear() {
+	clear(s.store)
+}
+
+// ShrinkToFit rebuilds the backing map at its current size, releasing the memory left by deleted items
+func (s *SimpleSetStore[T]) ShrinkToFit() {
+	store := make(map[T]struct{}, len(s.store))
+	for item := range s.store {
+		store[item] = struct{}{}
+	}
+	s.store = store
+}
+
 // Len returns the number of items in the store
 func (s *SimpleSetStore[T]) Len() int {
 	return len(s.store)
@@ -111,6 +142,17 @@ func (s *SimpleSetStore[T]) ForWithBreak(f func(item T) bool) {
 	}
 }
 
+// Iter returns an iterator over all the items in the store
+func (s *SimpleSetStore[T]) Iter() iter.Seq[T] {
+	return func(yield func(item T) bool) {
+		for item := range s.store {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
 func (s *SimpleSetStore[T]) MarshalJSON() ([]byte, error) {
 	return json.Marshal(s.Items())
 }